@@ -48,8 +48,8 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 	var body string
 	cookie, err := r.Cookie("token")
 	if err == nil {
-		session := client.SessionController().GetSession(cookie.Value)
-		if session != nil {
+		session, err := client.SessionController().GetSession(r.Context(), cookie.Value)
+		if err == nil {
 			var user *discord.OAuth2User
 			user, err = client.GetUser(session)
 			if err != nil {
@@ -88,7 +88,12 @@ func handleRoot(w http.ResponseWriter, r *http.Request) {
 }
 
 func handleLogin(w http.ResponseWriter, r *http.Request) {
-	http.Redirect(w, r, client.GenerateAuthorizationURL(baseURL+"/trylogin", discord.PermissionsNone, 0, false, discord.OAuth2ScopeIdentify, discord.OAuth2ScopeGuilds, discord.OAuth2ScopeEmail, discord.OAuth2ScopeConnections, discord.OAuth2ScopeWebhookIncoming), http.StatusMovedPermanently)
+	url, err := client.GenerateAuthorizationURL(r.Context(), baseURL+"/trylogin", discord.PermissionsNone, 0, false, discord.OAuth2ScopeIdentify, discord.OAuth2ScopeGuilds, discord.OAuth2ScopeEmail, discord.OAuth2ScopeConnections, discord.OAuth2ScopeWebhookIncoming)
+	if err != nil {
+		writeError(w, "error while generating authorization url", err)
+		return
+	}
+	http.Redirect(w, r, url, http.StatusMovedPermanently)
 }
 
 func handleTryLogin(w http.ResponseWriter, r *http.Request) {
@@ -99,7 +104,7 @@ func handleTryLogin(w http.ResponseWriter, r *http.Request) {
 	)
 	if code != "" && state != "" {
 		identifier := randStr(32)
-		_, err := client.StartSession(code, state, identifier)
+		_, err := client.StartSession(code, state, identifier, rest.WithCtx(r.Context()))
 		if err != nil {
 			writeError(w, "error while starting session", err)
 			return
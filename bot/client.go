@@ -68,6 +68,7 @@ type Client interface {
 	HasShardManager() bool
 
 	// Shard returns the gateway.Gateway the specific guildID runs on.
+	// If the guild runs on a shard of another cluster, discord.ErrShardNotFound is returned. Use sharding.ShardManager.IsLocalGuild to check this beforehand.
 	Shard(guildID snowflake.ID) (gateway.Gateway, error)
 
 	// Connect sends a discord.MessageDataVoiceStateUpdate to the specific gateway.Gateway and connects the bot to the specified channel.
@@ -95,6 +96,7 @@ type Client interface {
 	SetPresence(ctx context.Context, presenceUpdate gateway.MessageDataPresenceUpdate) error
 
 	// SetPresenceForShard sends a discord.MessageDataPresenceUpdate to the specific gateway.Gateway.
+	// If the shard is run by another cluster, it is forwarded by the sharding.Forwarder.
 	SetPresenceForShard(ctx context.Context, shardId int, presenceUpdate gateway.MessageDataPresenceUpdate) error
 
 	// MemberChunkingManager returns the MemberChunkingManager used by the Client.
@@ -203,8 +205,7 @@ func (c *clientImpl) OpenShardManager(ctx context.Context) error {
 	if c.shardManager == nil {
 		return discord.ErrNoShardManager
 	}
-	c.shardManager.Open(ctx)
-	return nil
+	return c.shardManager.Open(ctx)
 }
 
 func (c *clientImpl) ShardManager() sharding.ShardManager {
@@ -228,33 +229,21 @@ func (c *clientImpl) Shard(guildID snowflake.ID) (gateway.Gateway, error) {
 }
 
 func (c *clientImpl) Connect(ctx context.Context, guildID snowflake.ID, channelID snowflake.ID) error {
-	shard, err := c.Shard(guildID)
-	if err != nil {
-		return err
-	}
-	return shard.Send(ctx, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{
+	return c.sendToGuild(ctx, guildID, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{
 		GuildID:   guildID,
 		ChannelID: &channelID,
 	})
 }
 
 func (c *clientImpl) Disconnect(ctx context.Context, guildID snowflake.ID) error {
-	shard, err := c.Shard(guildID)
-	if err != nil {
-		return err
-	}
-	return shard.Send(ctx, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{
+	return c.sendToGuild(ctx, guildID, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{
 		GuildID:   guildID,
 		ChannelID: nil,
 	})
 }
 
 func (c *clientImpl) RequestMembers(ctx context.Context, guildID snowflake.ID, presence bool, nonce string, userIDs ...snowflake.ID) error {
-	shard, err := c.Shard(guildID)
-	if err != nil {
-		return err
-	}
-	return shard.Send(ctx, gateway.OpcodeRequestGuildMembers, gateway.MessageDataRequestGuildMembers{
+	return c.sendToGuild(ctx, guildID, gateway.OpcodeRequestGuildMembers, gateway.MessageDataRequestGuildMembers{
 		GuildID:   guildID,
 		Presences: presence,
 		UserIDs:   userIDs,
@@ -263,11 +252,7 @@ func (c *clientImpl) RequestMembers(ctx context.Context, guildID snowflake.ID, p
 }
 
 func (c *clientImpl) RequestMembersWithQuery(ctx context.Context, guildID snowflake.ID, presence bool, nonce string, query string, limit int) error {
-	shard, err := c.Shard(guildID)
-	if err != nil {
-		return err
-	}
-	return shard.Send(ctx, gateway.OpcodeRequestGuildMembers, gateway.MessageDataRequestGuildMembers{
+	return c.sendToGuild(ctx, guildID, gateway.OpcodeRequestGuildMembers, gateway.MessageDataRequestGuildMembers{
 		GuildID:   guildID,
 		Query:     &query,
 		Limit:     &limit,
@@ -287,11 +272,17 @@ func (c *clientImpl) SetPresenceForShard(ctx context.Context, shardId int, prese
 	if !c.HasShardManager() {
 		return discord.ErrNoShardManager
 	}
-	shard := c.shardManager.Shard(shardId)
-	if shard == nil {
-		return discord.ErrShardNotFound
+	return c.shardManager.SendToShard(ctx, shardId, gateway.OpcodePresenceUpdate, presenceUpdate)
+}
+
+// sendToGuild sends the gateway command to the gateway.Gateway of the given guild or lets the sharding.ShardManager forward it to the cluster running the guild.
+func (c *clientImpl) sendToGuild(ctx context.Context, guildID snowflake.ID, op gateway.Opcode, data gateway.MessageData) error {
+	if c.HasGateway() {
+		return c.gateway.Send(ctx, op, data)
+	} else if c.HasShardManager() {
+		return c.shardManager.SendToGuild(ctx, guildID, op, data)
 	}
-	return shard.Send(ctx, gateway.OpcodePresenceUpdate, presenceUpdate)
+	return discord.ErrNoGatewayOrShardManager
 }
 
 func (c *clientImpl) MemberChunkingManager() MemberChunkingManager {
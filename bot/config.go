@@ -11,6 +11,7 @@ import (
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/disgo/sharding"
 	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
 )
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -34,6 +35,7 @@ type Config struct {
 	EventManagerConfigOpts []EventManagerConfigOpt
 
 	Gateway           gateway.Gateway
+	GatewayCreateFunc gateway.CreateFunc
 	GatewayConfigOpts []gateway.ConfigOpt
 
 	ShardManager           sharding.ShardManager
@@ -128,6 +130,14 @@ func WithDefaultGateway() ConfigOpt {
 	}
 }
 
+// WithGatewayCreateFunc sets the function which is used to create the gateway.Gateway, e.g. gateway.NewRelayCreateFunc.
+// The gateway url is not fetched from Discord in this case.
+func WithGatewayCreateFunc(gatewayCreateFunc gateway.CreateFunc) ConfigOpt {
+	return func(config *Config) {
+		config.GatewayCreateFunc = gatewayCreateFunc
+	}
+}
+
 // WithGatewayConfigOpts lets you configure the default gateway.Gateway.
 func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	return func(config *Config) {
@@ -238,15 +248,18 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 	}
 	client.eventManager = config.EventManager
 
-	if config.Gateway == nil && len(config.GatewayConfigOpts) > 0 {
-		var gatewayRs *discord.Gateway
-		gatewayRs, err = client.restServices.GetGateway()
-		if err != nil {
-			return nil, err
+	if config.Gateway == nil && (len(config.GatewayConfigOpts) > 0 || config.GatewayCreateFunc != nil) {
+		if config.GatewayCreateFunc == nil {
+			var gatewayRs *discord.Gateway
+			gatewayRs, err = client.restServices.GetGateway()
+			if err != nil {
+				return nil, err
+			}
+			config.GatewayCreateFunc = gateway.New
+			config.GatewayConfigOpts = append([]gateway.ConfigOpt{gateway.WithURL(gatewayRs.URL)}, config.GatewayConfigOpts...)
 		}
 
 		config.GatewayConfigOpts = append([]gateway.ConfigOpt{
-			gateway.WithURL(gatewayRs.URL),
 			gateway.WithLogger(client.logger),
 			gateway.WithOS(os),
 			gateway.WithBrowser(name),
@@ -256,27 +269,15 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 			},
 		}, config.GatewayConfigOpts...)
 
-		config.Gateway = gateway.New(token, gatewayEventHandlerFunc(client), nil, config.GatewayConfigOpts...)
+		config.Gateway = config.GatewayCreateFunc(token, gatewayEventHandlerFunc(client), nil, config.GatewayConfigOpts...)
 	}
 	client.gateway = config.Gateway
 
 	if config.ShardManager == nil && len(config.ShardManagerConfigOpts) > 0 {
-		var gatewayBotRs *discord.GatewayBot
-		gatewayBotRs, err = client.restServices.GetGatewayBot()
-		if err != nil {
-			return nil, err
-		}
-
-		shardIDs := make([]int, gatewayBotRs.Shards)
-		for i := 0; i < gatewayBotRs.Shards-1; i++ {
-			shardIDs[i] = i
-		}
-
+		// the recommended shard count, max concurrency & gateway url are fetched when the sharding.ShardManager is opened
 		config.ShardManagerConfigOpts = append([]sharding.ConfigOpt{
-			sharding.WithShardCount(gatewayBotRs.Shards),
-			sharding.WithShardIDs(shardIDs...),
+			sharding.WithGatewayRest(client.restServices),
 			sharding.WithGatewayConfigOpts(
-				gateway.WithURL(gatewayBotRs.URL),
 				gateway.WithLogger(client.logger),
 				gateway.WithOS(os),
 				gateway.WithBrowser(name),
@@ -286,8 +287,16 @@ func BuildClient(token string, config Config, gatewayEventHandlerFunc func(clien
 				},
 			),
 			sharding.WithLogger(client.logger),
+			sharding.WithGuildCachedFunc(func(guildID snowflake.ID) bool {
+				// without a guild cache, there is no way to tell which guilds were already dispatched
+				if !client.caches.CacheFlags().Has(cache.FlagGuilds) {
+					return true
+				}
+				_, ok := client.caches.Guilds().Get(guildID)
+				return ok
+			}),
 			func(config *sharding.Config) {
-				config.RateRateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(client.logger), sharding.WithMaxConcurrency(gatewayBotRs.SessionStartLimit.MaxConcurrency)}, config.RateRateLimiterConfigOpts...)
+				config.RateRateLimiterConfigOpts = append([]sharding.RateLimiterConfigOpt{sharding.WithRateLimiterLogger(client.logger)}, config.RateRateLimiterConfigOpts...)
 			},
 		}, config.ShardManagerConfigOpts...)
 
@@ -0,0 +1,118 @@
+// Package component provides sessions for interactive messages with buttons & select menus.
+// A session is bound to a View which handles the components of it. The state of a session is either signed into the discord.CustomID of its components or kept in a Store.
+package component
+
+import (
+	"context"
+	"errors"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/log"
+)
+
+// MaxCustomIDLength is the maximum length of a discord.CustomID allowed by Discord.
+const MaxCustomIDLength = 100
+
+var (
+	// ErrViewNotFound is returned when a Session is created for an unknown View.
+	ErrViewNotFound = errors.New("view not found")
+
+	// ErrCustomIDTooLong is returned when the state of a Session does not fit into a discord.CustomID.
+	ErrCustomIDTooLong = errors.New("custom id exceeds 100 characters. use a smaller state or a Store")
+
+	// ErrInvalidCustomID is returned when a discord.CustomID could not be decoded or its signature is invalid.
+	ErrInvalidCustomID = errors.New("invalid component custom id")
+
+	// ErrSessionNotFound is returned when the Session of a discord.CustomID is not in the Store anymore.
+	ErrSessionNotFound = errors.New("component session not found")
+)
+
+// Handler handles a single action of a View.
+type Handler func(e *Event) error
+
+// View describes an interactive message and handles its components by action.
+// Names of views & actions must not contain ':'.
+type View struct {
+	// Name is the unique name of the View.
+	Name string
+
+	// Handlers are the Handler(s) by action name.
+	Handlers map[string]Handler
+
+	// OnExpire is called after the components of an expired Session got disabled.
+	OnExpire func(session *Session)
+
+	// RemoveOnExpire removes the components of an expired Session instead of disabling them.
+	RemoveOnExpire bool
+}
+
+func (v View) expireComponents(containers []discord.ContainerComponent) []discord.ContainerComponent {
+	if v.RemoveOnExpire {
+		return []discord.ContainerComponent{}
+	}
+	return DisableComponents(containers)
+}
+
+// Event is passed to a Handler when a component of its View was used.
+type Event struct {
+	*events.ComponentInteractionCreate
+
+	// Session is the Session the component belongs to. Changes to it are persisted after the Handler returned.
+	Session *Session
+
+	// Action is the action of the used component.
+	Action string
+}
+
+// Manager routes component interactions to the View of their Session.
+// It needs to be added as bot.EventListener to the bot.Client.
+type Manager interface {
+	bot.EventListener
+
+	// Logger returns the logger used by the Manager.
+	Logger() log.Logger
+
+	// AddViews adds the given View(s) to the Manager.
+	AddViews(views ...View)
+
+	// RemoveViews removes the View(s) with the given names from the Manager.
+	RemoveViews(names ...string)
+
+	// NewSession creates a new Session for the View with the given name & state.
+	NewSession(view string, state string, opts ...SessionOpt) (*Session, error)
+
+	// EndSession ends the Session, disables its components and stops tracking it.
+	EndSession(ctx context.Context, session *Session) error
+
+	// Close stops tracking all Session(s). Components of them are not disabled.
+	Close(ctx context.Context)
+}
+
+// DisableComponents returns a copy of the given discord.ContainerComponent(s) with all buttons & select menus disabled.
+// Link buttons are kept enabled as they don't create interactions.
+func DisableComponents(containers []discord.ContainerComponent) []discord.ContainerComponent {
+	disabled := make([]discord.ContainerComponent, 0, len(containers))
+	for _, container := range containers {
+		actionRow, ok := container.(discord.ActionRowComponent)
+		if !ok {
+			disabled = append(disabled, container)
+			continue
+		}
+		newActionRow := make(discord.ActionRowComponent, len(actionRow))
+		for i, component := range actionRow {
+			switch c := component.(type) {
+			case discord.ButtonComponent:
+				if c.Style != discord.ButtonStyleLink {
+					component = c.AsDisabled()
+				}
+			case discord.SelectMenuComponent:
+				component = c.AsDisabled()
+			}
+			newActionRow[i] = component
+		}
+		disabled = append(disabled, newActionRow)
+	}
+	return disabled
+}
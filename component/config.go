@@ -0,0 +1,99 @@
+package component
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:     log.Default(),
+		Prefix:     "cs",
+		DefaultTTL: 15 * time.Minute,
+		UnauthorizedMessage: func(e *Event) discord.MessageCreate {
+			return discord.NewMessageCreateBuilder().
+				SetContent("You are not allowed to use this.").
+				SetEphemeral(true).
+				Build()
+		},
+	}
+}
+
+// Config lets you configure your Manager instance.
+type Config struct {
+	Logger log.Logger
+
+	// Prefix is prepended to all discord.CustomID(s) created by the Manager, so they can be told apart from others.
+	Prefix string
+
+	// Secret is used to sign the state into the discord.CustomID. If it is empty, the state is kept in the Store instead.
+	Secret []byte
+
+	// Store keeps the Session(s) when no Secret is configured.
+	Store Store
+
+	// DefaultTTL is the time after which a Session expires if not configured otherwise.
+	DefaultTTL time.Duration
+
+	// UnauthorizedMessage is sent when a user who is not an owner of the Session uses a component.
+	UnauthorizedMessage func(e *Event) discord.MessageCreate
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Manager.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.Secret) == 0 && c.Store == nil {
+		c.Store = NewMemoryStore()
+	}
+}
+
+// WithLogger sets the Logger of the Manager.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithPrefix sets the prefix of all discord.CustomID(s) created by the Manager.
+func WithPrefix(prefix string) ConfigOpt {
+	return func(config *Config) {
+		config.Prefix = prefix
+	}
+}
+
+// WithSecret sets the secret used to sign the state into the discord.CustomID.
+// Sessions signed this way survive restarts without any Store.
+func WithSecret(secret []byte) ConfigOpt {
+	return func(config *Config) {
+		config.Secret = secret
+	}
+}
+
+// WithStore sets the Store which keeps the Session(s) server side.
+func WithStore(store Store) ConfigOpt {
+	return func(config *Config) {
+		config.Store = store
+	}
+}
+
+// WithDefaultTTL sets the time after which a Session expires if not configured otherwise.
+func WithDefaultTTL(ttl time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.DefaultTTL = ttl
+	}
+}
+
+// WithUnauthorizedMessage sets the message which is sent when a user who is not an owner of the Session uses a component.
+func WithUnauthorizedMessage(unauthorizedMessage func(e *Event) discord.MessageCreate) ConfigOpt {
+	return func(config *Config) {
+		config.UnauthorizedMessage = unauthorizedMessage
+	}
+}
@@ -0,0 +1,302 @@
+package component
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/log"
+)
+
+var _ Manager = (*managerImpl)(nil)
+
+// New creates a new Manager with the given bot.Client and ConfigOpt(s).
+func New(client bot.Client, opts ...ConfigOpt) Manager {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	return &managerImpl{
+		client: client,
+		config: *config,
+		views:  map[string]View{},
+		timers: map[string]*time.Timer{},
+	}
+}
+
+type managerImpl struct {
+	client bot.Client
+	config Config
+
+	views   map[string]View
+	viewsMu sync.RWMutex
+
+	timers   map[string]*time.Timer
+	timersMu sync.Mutex
+}
+
+func (m *managerImpl) Logger() log.Logger {
+	return m.config.Logger
+}
+
+func (m *managerImpl) AddViews(views ...View) {
+	m.viewsMu.Lock()
+	defer m.viewsMu.Unlock()
+	for _, view := range views {
+		m.views[view.Name] = view
+	}
+}
+
+func (m *managerImpl) RemoveViews(names ...string) {
+	m.viewsMu.Lock()
+	defer m.viewsMu.Unlock()
+	for _, name := range names {
+		delete(m.views, name)
+	}
+}
+
+func (m *managerImpl) view(name string) (View, bool) {
+	m.viewsMu.RLock()
+	defer m.viewsMu.RUnlock()
+	view, ok := m.views[name]
+	return view, ok
+}
+
+func (m *managerImpl) signed() bool {
+	return len(m.config.Secret) > 0
+}
+
+func (m *managerImpl) customID(session *Session, action string) string {
+	token := session.ID
+	if m.signed() {
+		token = encodeToken(m.config.Secret, session, action)
+	}
+	return m.config.Prefix + ":" + session.View + ":" + action + ":" + token
+}
+
+func (m *managerImpl) checkLength(session *Session) error {
+	view, ok := m.view(session.View)
+	if !ok {
+		return ErrViewNotFound
+	}
+	for action := range view.Handlers {
+		if len(m.customID(session, action)) > MaxCustomIDLength {
+			return ErrCustomIDTooLong
+		}
+	}
+	return nil
+}
+
+func (m *managerImpl) NewSession(view string, state string, opts ...SessionOpt) (*Session, error) {
+	config := &SessionConfig{TTL: m.config.DefaultTTL}
+	config.Apply(opts)
+
+	session := &Session{
+		View:    view,
+		State:   state,
+		Owners:  config.Owners,
+		manager: m,
+	}
+	if config.TTL > 0 {
+		session.ExpiresAt = time.Now().Add(config.TTL)
+	}
+	if !m.signed() {
+		session.ID = insecurerandstr.RandStr(16)
+	}
+	if err := m.checkLength(session); err != nil {
+		return nil, err
+	}
+	if !m.signed() {
+		m.track(session)
+	}
+	return session, nil
+}
+
+func (m *managerImpl) EndSession(ctx context.Context, session *Session) error {
+	session.ended = true
+	m.untrack(session.trackingKey())
+	if session.ID != "" {
+		m.config.Store.Delete(session.ID)
+	}
+	return m.disable(session, rest.WithCtx(ctx))
+}
+
+func (m *managerImpl) Close(_ context.Context) {
+	m.timersMu.Lock()
+	defer m.timersMu.Unlock()
+	for key, timer := range m.timers {
+		timer.Stop()
+		delete(m.timers, key)
+	}
+}
+
+func (m *managerImpl) track(session *Session) {
+	if session.ID != "" {
+		m.config.Store.Put(session)
+	}
+	key := session.trackingKey()
+	if key == "" || session.ExpiresAt.IsZero() {
+		return
+	}
+
+	m.timersMu.Lock()
+	defer m.timersMu.Unlock()
+	if timer, ok := m.timers[key]; ok {
+		timer.Stop()
+	}
+	m.timers[key] = time.AfterFunc(time.Until(session.ExpiresAt), func() {
+		m.expire(key, session)
+	})
+}
+
+func (m *managerImpl) untrack(key string) {
+	m.timersMu.Lock()
+	defer m.timersMu.Unlock()
+	if timer, ok := m.timers[key]; ok {
+		timer.Stop()
+		delete(m.timers, key)
+	}
+}
+
+func (m *managerImpl) expire(key string, session *Session) {
+	m.timersMu.Lock()
+	delete(m.timers, key)
+	m.timersMu.Unlock()
+
+	if session.ID != "" {
+		// the stored session might have been updated since the timer was started
+		if stored, ok := m.config.Store.Get(session.ID); ok {
+			session = stored
+			session.manager = m
+		}
+		if !session.Expired() {
+			return
+		}
+		m.config.Store.Delete(session.ID)
+	}
+
+	m.Logger().Debugf("component session of view %s expired", session.View)
+	if err := m.disable(session); err != nil {
+		m.Logger().Errorf("failed to disable components of expired session of view %s: %s", session.View, err)
+	}
+
+	if view, ok := m.view(session.View); ok && view.OnExpire != nil {
+		view.OnExpire(session)
+	}
+}
+
+func (m *managerImpl) expireComponents(session *Session, containers []discord.ContainerComponent) []discord.ContainerComponent {
+	view, _ := m.view(session.View)
+	return view.expireComponents(containers)
+}
+
+func (m *managerImpl) disable(session *Session, opts ...rest.RequestOpt) error {
+	var (
+		message *discord.Message
+		err     error
+	)
+	if session.InteractionToken != "" {
+		if message, err = m.client.Rest().GetInteractionResponse(m.client.ApplicationID(), session.InteractionToken, opts...); err != nil {
+			return err
+		}
+		components := m.expireComponents(session, message.Components)
+		_, err = m.client.Rest().UpdateInteractionResponse(m.client.ApplicationID(), session.InteractionToken, discord.MessageUpdate{Components: &components}, opts...)
+		return err
+	}
+	if session.MessageID == 0 {
+		return nil
+	}
+	if message, err = m.client.Rest().GetMessage(session.ChannelID, session.MessageID, opts...); err != nil {
+		return err
+	}
+	components := m.expireComponents(session, message.Components)
+	_, err = m.client.Rest().UpdateMessage(session.ChannelID, session.MessageID, discord.MessageUpdate{Components: &components}, opts...)
+	return err
+}
+
+func (m *managerImpl) session(view string, action string, token string) (*Session, error) {
+	if m.signed() {
+		session, err := decodeToken(m.config.Secret, view, action, token)
+		if err != nil {
+			return nil, err
+		}
+		session.manager = m
+		return session, nil
+	}
+	session, ok := m.config.Store.Get(token)
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	session.manager = m
+	return session, nil
+}
+
+func (m *managerImpl) OnEvent(event bot.Event) {
+	e, ok := event.(*events.ComponentInteractionCreate)
+	if !ok {
+		return
+	}
+	prefix, customID, ok := strings.Cut(e.Data.CustomID().String(), ":")
+	if !ok || prefix != m.config.Prefix {
+		return
+	}
+	parts := strings.SplitN(customID, ":", 3)
+	if len(parts) != 3 {
+		m.Logger().Debugf("received component interaction with malformed custom id %s", e.Data.CustomID())
+		m.expireInteraction(e, View{})
+		return
+	}
+	viewName, action, token := parts[0], parts[1], parts[2]
+
+	view, ok := m.view(viewName)
+	if !ok {
+		m.Logger().Debugf("received component interaction for unknown view %s", viewName)
+		m.expireInteraction(e, View{})
+		return
+	}
+
+	session, err := m.session(viewName, action, token)
+	if err != nil || session.Expired() {
+		m.expireInteraction(e, view)
+		return
+	}
+
+	if !session.IsOwner(e.User().ID) {
+		if err = e.CreateMessage(m.config.UnauthorizedMessage(&Event{ComponentInteractionCreate: e, Session: session, Action: action})); err != nil {
+			m.Logger().Error("failed to respond to unauthorized component interaction: ", err)
+		}
+		return
+	}
+
+	handler, ok := view.Handlers[action]
+	if !ok {
+		m.Logger().Debugf("received component interaction for unknown action %s of view %s", action, viewName)
+		// the session is still valid, so we only acknowledge the interaction
+		if err = e.DeferUpdateMessage(); err != nil {
+			m.Logger().Error("failed to respond to component interaction for unknown action: ", err)
+		}
+		return
+	}
+
+	session.ChannelID = e.ChannelID()
+	session.MessageID = e.Message.ID
+	if err = handler(&Event{ComponentInteractionCreate: e, Session: session, Action: action}); err != nil {
+		m.Logger().Errorf("error while handling action %s of view %s: %s", action, viewName, err)
+	}
+	if !session.ended {
+		m.track(session)
+	}
+}
+
+// expireInteraction responds to a component interaction which can't be handled anymore by disabling the components the user clicked on, so Discord doesn't show it as failed.
+func (m *managerImpl) expireInteraction(e *events.ComponentInteractionCreate, view View) {
+	components := view.expireComponents(e.Message.Components)
+	if err := e.UpdateMessage(discord.MessageUpdate{Components: &components}); err != nil {
+		m.Logger().Error("failed to disable components of expired session: ", err)
+	}
+}
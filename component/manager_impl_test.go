@@ -0,0 +1,80 @@
+package component
+
+import (
+	"context"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newComponentEvent returns a ComponentInteractionCreate for a button with the given custom id which records the response type.
+func newComponentEvent(t *testing.T, customID string, responseType *discord.InteractionResponseType) *events.ComponentInteractionCreate {
+	var interaction discord.ComponentInteraction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "1",
+		"application_id": "2",
+		"type": 3,
+		"token": "token",
+		"version": 1,
+		"channel_id": "3",
+		"user": {"id": "4", "username": "user"},
+		"data": {"component_type": 2, "custom_id": "`+customID+`"},
+		"message": {"id": "5", "channel_id": "3", "components": [{"type": 1, "components": [{"type": 2, "style": 1, "custom_id": "`+customID+`", "label": "click"}]}]}
+	}`), &interaction))
+
+	return &events.ComponentInteractionCreate{
+		GenericEvent:         events.NewGenericEvent(nil, 0, 0),
+		ComponentInteraction: interaction,
+		Respond: func(rType discord.InteractionResponseType, _ discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+			*responseType = rType
+			return nil
+		},
+	}
+}
+
+func TestManager_OnEvent(t *testing.T) {
+	var handled bool
+	m := New(nil)
+	defer m.Close(context.Background())
+	m.AddViews(View{
+		Name: "pages",
+		Handlers: map[string]Handler{
+			"next": func(e *Event) error {
+				handled = true
+				return e.DeferUpdateMessage()
+			},
+		},
+	})
+	session, err := m.NewSession("pages", "page=1")
+	require.NoError(t, err)
+
+	var responseType discord.InteractionResponseType
+	m.OnEvent(newComponentEvent(t, session.CustomID("next").String(), &responseType))
+	assert.True(t, handled)
+	assert.Equal(t, discord.InteractionResponseTypeDeferredUpdateMessage, responseType)
+
+	// every interaction with our prefix needs a response, otherwise Discord shows it as failed
+	tests := map[string]struct {
+		customID     string
+		responseType discord.InteractionResponseType
+	}{
+		"unknown action":     {customID: "cs:pages:prev:" + session.ID, responseType: discord.InteractionResponseTypeDeferredUpdateMessage},
+		"unknown view":       {customID: "cs:other:next:" + session.ID, responseType: discord.InteractionResponseTypeUpdateMessage},
+		"malformed":          {customID: "cs:pages", responseType: discord.InteractionResponseTypeUpdateMessage},
+		"expired session":    {customID: "cs:pages:next:unknown", responseType: discord.InteractionResponseTypeUpdateMessage},
+		"other prefix":       {customID: "other:pages:next:" + session.ID},
+		"not a component id": {customID: "button"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var responseType discord.InteractionResponseType
+			m.OnEvent(newComponentEvent(t, tt.customID, &responseType))
+			assert.Equal(t, tt.responseType, responseType)
+		})
+	}
+}
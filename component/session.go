@@ -0,0 +1,202 @@
+package component
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Session is a single interactive message of a View.
+type Session struct {
+	// ID identifies the Session in the Store. It is empty if the state is signed into the discord.CustomID.
+	ID string
+
+	// View is the name of the View the Session belongs to.
+	View string
+
+	// State is the state of the Session. Handlers can change it to move the Session into another state.
+	State string
+
+	// Owners are the users allowed to use the components. If empty, everyone is allowed.
+	Owners []snowflake.ID
+
+	// ExpiresAt is the time the Session expires and its components get disabled.
+	ExpiresAt time.Time
+
+	// ChannelID & MessageID are the message the Session is bound to.
+	ChannelID snowflake.ID
+	MessageID snowflake.ID
+
+	// InteractionToken is the token of the interaction the message was created with. It is used to edit ephemeral messages.
+	InteractionToken string
+
+	manager *managerImpl
+	ended   bool
+}
+
+// CustomID returns the discord.CustomID for the given action of the Session.
+// With a configured secret, the discord.CustomID changes with the State and needs to be re-rendered after changing it.
+func (s *Session) CustomID(action string) discord.CustomID {
+	return discord.CustomID(s.manager.customID(s, action))
+}
+
+// SetState sets the State of the Session and makes sure it still fits into the discord.CustomID of all actions.
+func (s *Session) SetState(state string) error {
+	oldState := s.State
+	s.State = state
+	if err := s.manager.checkLength(s); err != nil {
+		s.State = oldState
+		return err
+	}
+	return nil
+}
+
+// Expired returns whether the Session is expired.
+func (s *Session) Expired() bool {
+	return !s.ExpiresAt.IsZero() && time.Now().After(s.ExpiresAt)
+}
+
+// IsOwner returns whether the given user is allowed to use the components of the Session.
+func (s *Session) IsOwner(userID snowflake.ID) bool {
+	if len(s.Owners) == 0 {
+		return true
+	}
+	for _, owner := range s.Owners {
+		if owner == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// Bind binds the Session to the given message, so its components can be disabled when the Session expires.
+func (s *Session) Bind(message discord.Message) {
+	s.ChannelID = message.ChannelID
+	s.MessageID = message.ID
+	s.manager.track(s)
+}
+
+// BindInteraction binds the Session to the original response of the interaction with the given token.
+// This is needed for ephemeral messages which can only be edited with the interaction token for 15 minutes.
+func (s *Session) BindInteraction(interactionToken string) {
+	s.InteractionToken = interactionToken
+	s.manager.track(s)
+}
+
+func (s *Session) trackingKey() string {
+	if s.ID != "" {
+		return s.ID
+	}
+	if s.MessageID != 0 {
+		return s.MessageID.String()
+	}
+	return s.InteractionToken
+}
+
+// SessionConfig lets you configure a new Session.
+type SessionConfig struct {
+	Owners []snowflake.ID
+	TTL    time.Duration
+}
+
+// SessionOpt is a type alias for a function that takes a SessionConfig and is used to configure a new Session.
+type SessionOpt func(config *SessionConfig)
+
+// Apply applies the given SessionOpt(s) to the SessionConfig
+func (c *SessionConfig) Apply(opts []SessionOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithOwners restricts the components of the Session to the given users.
+func WithOwners(owners ...snowflake.ID) SessionOpt {
+	return func(config *SessionConfig) {
+		config.Owners = append(config.Owners, owners...)
+	}
+}
+
+// WithTTL sets the time after which the Session expires.
+func WithTTL(ttl time.Duration) SessionOpt {
+	return func(config *SessionConfig) {
+		config.TTL = ttl
+	}
+}
+
+const macSize = 8
+
+// encodeToken encodes the expiry, owners & state of the Session and signs it together with the view & action.
+func encodeToken(secret []byte, session *Session, action string) string {
+	payload := make([]byte, 0, 2*binary.MaxVarintLen64+8*len(session.Owners)+len(session.State))
+	var expiresAt uint64
+	if !session.ExpiresAt.IsZero() {
+		expiresAt = uint64(session.ExpiresAt.Unix())
+	}
+	payload = appendUvarint(payload, expiresAt)
+	payload = appendUvarint(payload, uint64(len(session.Owners)))
+	for _, owner := range session.Owners {
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(owner))
+		payload = append(payload, buf[:]...)
+	}
+	payload = append(payload, session.State...)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(secret, session.View, action, payload))
+}
+
+// decodeToken verifies the token and returns the Session encoded in it.
+func decodeToken(secret []byte, view string, action string, token string) (*Session, error) {
+	rawPayload, rawMAC, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidCustomID
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(rawPayload)
+	if err != nil {
+		return nil, ErrInvalidCustomID
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(rawMAC)
+	if err != nil || !hmac.Equal(mac, sign(secret, view, action, payload)) {
+		return nil, ErrInvalidCustomID
+	}
+
+	expiresAt, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return nil, ErrInvalidCustomID
+	}
+	payload = payload[n:]
+	ownerCount, n := binary.Uvarint(payload)
+	if n <= 0 || uint64(len(payload[n:])) < ownerCount*8 {
+		return nil, ErrInvalidCustomID
+	}
+	payload = payload[n:]
+
+	session := &Session{View: view}
+	if expiresAt > 0 {
+		session.ExpiresAt = time.Unix(int64(expiresAt), 0)
+	}
+	for i := uint64(0); i < ownerCount; i++ {
+		session.Owners = append(session.Owners, snowflake.ID(binary.BigEndian.Uint64(payload)))
+		payload = payload[8:]
+	}
+	session.State = string(payload)
+	return session, nil
+}
+
+func sign(secret []byte, view string, action string, payload []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(view + ":" + action + ":"))
+	h.Write(payload)
+	return h.Sum(nil)[:macSize]
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], v)
+	return append(b, buf[:n]...)
+}
@@ -0,0 +1,53 @@
+package component
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	secret := []byte("secret")
+	session := &Session{
+		View:      "pages",
+		State:     "page=2",
+		Owners:    []snowflake.ID{170939974227591168},
+		ExpiresAt: time.Unix(1700000000, 0),
+	}
+
+	token := encodeToken(secret, session, "next")
+
+	decoded, err := decodeToken(secret, "pages", "next", token)
+	assert.NoError(t, err)
+	assert.Equal(t, session.State, decoded.State)
+	assert.Equal(t, session.Owners, decoded.Owners)
+	assert.True(t, session.ExpiresAt.Equal(decoded.ExpiresAt))
+
+	_, err = decodeToken(secret, "pages", "prev", token)
+	assert.ErrorIs(t, err, ErrInvalidCustomID)
+
+	_, err = decodeToken([]byte("other"), "pages", "next", token)
+	assert.ErrorIs(t, err, ErrInvalidCustomID)
+
+	_, err = decodeToken(secret, "pages", "next", "A"+token)
+	assert.ErrorIs(t, err, ErrInvalidCustomID)
+}
+
+func TestDisableComponents(t *testing.T) {
+	components := []discord.ContainerComponent{
+		discord.NewActionRow(
+			discord.NewPrimaryButton("next", "next"),
+			discord.NewLinkButton("docs", "https://example.com"),
+		),
+	}
+
+	disabled := DisableComponents(components)
+
+	row := disabled[0].(discord.ActionRowComponent)
+	assert.True(t, row[0].(discord.ButtonComponent).Disabled)
+	assert.False(t, row[1].(discord.ButtonComponent).Disabled)
+	assert.False(t, components[0].(discord.ActionRowComponent)[0].(discord.ButtonComponent).Disabled)
+}
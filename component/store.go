@@ -0,0 +1,51 @@
+package component
+
+import (
+	"sync"
+)
+
+var _ Store = (*memoryStore)(nil)
+
+// Store keeps Session(s) server side. Implementations need to be safe for concurrent use.
+type Store interface {
+	// Get returns the Session with the given id.
+	Get(id string) (*Session, bool)
+
+	// Put creates or updates the given Session.
+	Put(session *Session)
+
+	// Delete deletes the Session with the given id.
+	Delete(id string)
+}
+
+// NewMemoryStore returns a new Store which keeps all Session(s) in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{sessions: map[string]Session{}}
+}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+func (s *memoryStore) Get(id string) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return &session, true
+}
+
+func (s *memoryStore) Put(session *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.ID] = *session
+}
+
+func (s *memoryStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+}
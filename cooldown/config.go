@@ -0,0 +1,89 @@
+package cooldown
+
+import (
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Scope:     ScopeUser,
+		Algorithm: AlgorithmFixedWindow,
+		Limit:     1,
+		Window:    5 * time.Second,
+	}
+}
+
+// Config lets you configure your Cooldown instance.
+type Config struct {
+	// Scope decides which uses share a bucket.
+	Scope Scope
+
+	// Algorithm decides how uses are counted in a bucket.
+	Algorithm Algorithm
+
+	// Limit is the number of uses allowed per Window.
+	Limit int
+
+	// Window is the time in which Limit uses are allowed.
+	Window time.Duration
+
+	// ExemptPermissions exempts members with any of the permissions from the Cooldown.
+	ExemptPermissions discord.Permissions
+
+	// ExemptRoles exempts members with any of the roles from the Cooldown.
+	ExemptRoles []snowflake.ID
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Cooldown.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Limit < 1 {
+		c.Limit = 1
+	}
+}
+
+// WithScope sets the Scope of the Cooldown.
+func WithScope(scope Scope) ConfigOpt {
+	return func(config *Config) {
+		config.Scope = scope
+	}
+}
+
+// WithAlgorithm sets the Algorithm of the Cooldown.
+func WithAlgorithm(algorithm Algorithm) ConfigOpt {
+	return func(config *Config) {
+		config.Algorithm = algorithm
+	}
+}
+
+// WithRate sets the number of uses allowed per window.
+func WithRate(limit int, window time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.Limit = limit
+		config.Window = window
+	}
+}
+
+// WithExemptPermissions exempts members with any of the given permissions from the Cooldown.
+// This requires the cache.FlagRoles to be set.
+func WithExemptPermissions(permissions ...discord.Permissions) ConfigOpt {
+	return func(config *Config) {
+		config.ExemptPermissions = config.ExemptPermissions.Add(permissions...)
+	}
+}
+
+// WithExemptRoles exempts members with any of the given roles from the Cooldown.
+func WithExemptRoles(roleIDs ...snowflake.ID) ConfigOpt {
+	return func(config *Config) {
+		config.ExemptRoles = append(config.ExemptRoles, roleIDs...)
+	}
+}
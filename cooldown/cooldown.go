@@ -0,0 +1,133 @@
+// Package cooldown provides rate limiting for commands by user, member, channel, guild or globally.
+// It does not depend on a specific command framework and can be used from any interaction or message listener.
+package cooldown
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Scope decides which uses share a bucket.
+type Scope int
+
+// All Scope(s)
+const (
+	// ScopeGlobal shares one bucket between all uses.
+	ScopeGlobal Scope = iota
+
+	// ScopeUser has one bucket per user across all guilds.
+	ScopeUser
+
+	// ScopeMember has one bucket per user in each guild. Uses in DMs share one bucket per user.
+	ScopeMember
+
+	// ScopeChannel has one bucket per channel.
+	ScopeChannel
+
+	// ScopeGuild has one bucket per guild. Uses in DMs have one bucket per channel.
+	ScopeGuild
+)
+
+// Algorithm decides how uses are counted in a bucket.
+type Algorithm int
+
+// All Algorithm(s)
+const (
+	// AlgorithmFixedWindow allows Config.Limit uses per Config.Window. The window starts with the first use.
+	AlgorithmFixedWindow Algorithm = iota
+
+	// AlgorithmTokenBucket allows bursts of Config.Limit uses and refills them evenly over Config.Window.
+	AlgorithmTokenBucket
+)
+
+// Source describes who used a command where.
+type Source struct {
+	UserID    snowflake.ID
+	GuildID   *snowflake.ID
+	ChannelID snowflake.ID
+
+	// Member is the member who used the command. It is used to check exemptions and is nil in DMs.
+	Member *discord.Member
+
+	// Permissions are the permissions of the Member in the channel, which Discord sends with every interaction.
+	Permissions *discord.Permissions
+
+	// Caches are used to calculate the permissions of the Member if Permissions is nil.
+	Caches cache.Caches
+}
+
+// InteractionSource returns the Source of the given discord.Interaction.
+// The permissions of the member are taken from the interaction, so exemptions don't depend on the caches.
+func InteractionSource(interaction discord.Interaction) Source {
+	source := Source{
+		UserID:    interaction.User().ID,
+		GuildID:   interaction.GuildID(),
+		ChannelID: interaction.ChannelID(),
+	}
+	if member := interaction.Member(); member != nil {
+		source.Member = &member.Member
+		source.Permissions = &member.Permissions
+	}
+	return source
+}
+
+// MessageSource returns the Source of the given *events.MessageCreate.
+func MessageSource(e *events.MessageCreate) Source {
+	source := Source{
+		UserID:    e.Message.Author.ID,
+		GuildID:   e.GuildID,
+		ChannelID: e.ChannelID,
+		Caches:    e.Client().Caches(),
+	}
+	if e.Message.Member != nil && e.GuildID != nil {
+		member := *e.Message.Member
+		member.User = e.Message.Author
+		member.GuildID = *e.GuildID
+		source.Member = &member
+	}
+	return source
+}
+
+func (s Source) key(scope Scope) string {
+	switch scope {
+	case ScopeUser:
+		return s.UserID.String()
+	case ScopeMember:
+		if s.GuildID == nil {
+			return s.UserID.String()
+		}
+		return s.GuildID.String() + ":" + s.UserID.String()
+	case ScopeChannel:
+		return s.ChannelID.String()
+	case ScopeGuild:
+		if s.GuildID == nil {
+			return s.ChannelID.String()
+		}
+		return s.GuildID.String()
+	default:
+		return strconv.Itoa(int(ScopeGlobal))
+	}
+}
+
+// Cooldown keeps track of the uses of a command.
+type Cooldown interface {
+	// Take uses the bucket of the given Source once. If the bucket is exhausted, the time until the next use is allowed is returned together with false.
+	// Exempted members are always allowed.
+	Take(source Source) (time.Duration, bool)
+
+	// Reset resets the bucket of the given Source.
+	Reset(source Source)
+}
+
+// Message returns an ephemeral discord.MessageCreate telling the user when they can use the command again.
+func Message(remaining time.Duration) discord.MessageCreate {
+	return discord.NewMessageCreateBuilder().
+		SetContentf("You are on cooldown. Try again %s.", discord.TimestampStyleRelative.FormatTime(time.Now().Add(remaining))).
+		SetEphemeral(true).
+		Build()
+}
@@ -0,0 +1,140 @@
+package cooldown
+
+import (
+	"sync"
+	"time"
+)
+
+var _ Cooldown = (*cooldownImpl)(nil)
+
+// New creates a new Cooldown with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Cooldown {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	return &cooldownImpl{
+		config:  *config,
+		now:     time.Now,
+		buckets: map[string]*bucket{},
+	}
+}
+
+type bucket struct {
+	// start of the fixed window or time of the last refill of the token bucket
+	start time.Time
+
+	// uses in the fixed window or available tokens of the token bucket
+	count float64
+}
+
+type cooldownImpl struct {
+	config Config
+	now    func() time.Time
+
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+	mu          sync.Mutex
+}
+
+func (c *cooldownImpl) Take(source Source) (time.Duration, bool) {
+	if c.exempt(source) {
+		return 0, true
+	}
+	key := source.key(c.config.Scope)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.cleanup(now)
+
+	b, ok := c.buckets[key]
+	if !ok {
+		b = c.newBucket(now)
+		c.buckets[key] = b
+	}
+
+	if c.config.Algorithm == AlgorithmTokenBucket {
+		c.refill(b, now)
+		if b.count < 1 {
+			return time.Duration((1 - b.count) * float64(c.interval())), false
+		}
+		b.count--
+		return 0, true
+	}
+
+	if !now.Before(b.start.Add(c.config.Window)) {
+		b.start = now
+		b.count = 0
+	}
+	if int(b.count) >= c.config.Limit {
+		return b.start.Add(c.config.Window).Sub(now), false
+	}
+	b.count++
+	return 0, true
+}
+
+func (c *cooldownImpl) Reset(source Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.buckets, source.key(c.config.Scope))
+}
+
+func (c *cooldownImpl) exempt(source Source) bool {
+	if source.Member == nil {
+		return false
+	}
+	for _, roleID := range source.Member.RoleIDs {
+		for _, exemptRoleID := range c.config.ExemptRoles {
+			if roleID == exemptRoleID {
+				return true
+			}
+		}
+	}
+	if c.config.ExemptPermissions == 0 {
+		return false
+	}
+	if source.Permissions != nil {
+		return *source.Permissions&c.config.ExemptPermissions != 0
+	}
+	if source.Caches == nil {
+		return false
+	}
+	return source.Caches.GetMemberPermissions(*source.Member)&c.config.ExemptPermissions != 0
+}
+
+func (c *cooldownImpl) newBucket(now time.Time) *bucket {
+	if c.config.Algorithm == AlgorithmTokenBucket {
+		return &bucket{start: now, count: float64(c.config.Limit)}
+	}
+	return &bucket{start: now}
+}
+
+// interval returns the time it takes to refill one token.
+func (c *cooldownImpl) interval() time.Duration {
+	return c.config.Window / time.Duration(c.config.Limit)
+}
+
+func (c *cooldownImpl) refill(b *bucket, now time.Time) {
+	if interval := c.interval(); interval > 0 {
+		b.count += float64(now.Sub(b.start)) / float64(interval)
+	} else {
+		b.count = float64(c.config.Limit)
+	}
+	if b.count > float64(c.config.Limit) {
+		b.count = float64(c.config.Limit)
+	}
+	b.start = now
+}
+
+// cleanup removes all buckets which are back to their initial state once per window.
+func (c *cooldownImpl) cleanup(now time.Time) {
+	if now.Sub(c.lastCleanup) < c.config.Window {
+		return
+	}
+	c.lastCleanup = now
+	for key, b := range c.buckets {
+		if !now.Before(b.start.Add(c.config.Window)) {
+			delete(c.buckets, key)
+		}
+	}
+}
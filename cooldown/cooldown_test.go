@@ -0,0 +1,114 @@
+package cooldown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCooldown(now *time.Time, opts ...ConfigOpt) *cooldownImpl {
+	c := New(opts...).(*cooldownImpl)
+	c.now = func() time.Time {
+		return *now
+	}
+	return c
+}
+
+func TestCooldown_FixedWindow(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newTestCooldown(&now, WithRate(2, 10*time.Second))
+	source := Source{UserID: 1}
+
+	_, ok := c.Take(source)
+	assert.True(t, ok)
+	now = now.Add(time.Second)
+	_, ok = c.Take(source)
+	assert.True(t, ok)
+
+	now = now.Add(time.Second)
+	remaining, ok := c.Take(source)
+	assert.False(t, ok)
+	assert.Equal(t, 8*time.Second, remaining)
+
+	_, ok = c.Take(Source{UserID: 2})
+	assert.True(t, ok)
+
+	now = now.Add(8 * time.Second)
+	_, ok = c.Take(source)
+	assert.True(t, ok)
+}
+
+func TestCooldown_TokenBucket(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newTestCooldown(&now, WithAlgorithm(AlgorithmTokenBucket), WithRate(2, 10*time.Second))
+	source := Source{UserID: 1}
+
+	_, ok := c.Take(source)
+	assert.True(t, ok)
+	_, ok = c.Take(source)
+	assert.True(t, ok)
+
+	remaining, ok := c.Take(source)
+	assert.False(t, ok)
+	assert.Equal(t, 5*time.Second, remaining)
+
+	now = now.Add(5 * time.Second)
+	_, ok = c.Take(source)
+	assert.True(t, ok)
+	_, ok = c.Take(source)
+	assert.False(t, ok)
+}
+
+func TestCooldown_Scope(t *testing.T) {
+	now := time.Unix(1000, 0)
+	guildID := snowflake.ID(10)
+	c := newTestCooldown(&now, WithScope(ScopeChannel), WithExemptRoles(100))
+
+	_, ok := c.Take(Source{UserID: 1, GuildID: &guildID, ChannelID: 5})
+	assert.True(t, ok)
+	_, ok = c.Take(Source{UserID: 2, GuildID: &guildID, ChannelID: 5})
+	assert.False(t, ok)
+	_, ok = c.Take(Source{UserID: 2, GuildID: &guildID, ChannelID: 6})
+	assert.True(t, ok)
+	_, ok = c.Take(Source{UserID: 3, GuildID: &guildID, ChannelID: 5, Member: &discord.Member{RoleIDs: []snowflake.ID{100}}})
+	assert.True(t, ok)
+}
+
+func TestCooldown_ExemptPermissions(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := newTestCooldown(&now, WithExemptPermissions(discord.PermissionManageMessages))
+
+	// the interaction carries the permissions of the member, so the guild doesn't need to be cached
+	var interaction discord.ComponentInteraction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "1",
+		"application_id": "2",
+		"type": 3,
+		"token": "token",
+		"version": 1,
+		"guild_id": "10",
+		"channel_id": "5",
+		"member": {"user": {"id": "3", "username": "mod"}, "roles": [], "joined_at": "2021-01-01T00:00:00Z", "permissions": "8192"},
+		"data": {"component_type": 2, "custom_id": "button"}
+	}`), &interaction))
+	source := InteractionSource(interaction)
+	require.NotNil(t, source.Permissions)
+	for i := 0; i < 2; i++ {
+		_, ok := c.Take(source)
+		assert.True(t, ok)
+	}
+
+	// without permissions, they are calculated from the caches, which don't know the guild
+	guildID := snowflake.ID(10)
+	source = Source{UserID: 3, GuildID: &guildID, ChannelID: 5, Member: source.Member, Caches: cache.New()}
+	_, ok := c.Take(source)
+	assert.True(t, ok)
+	_, ok = c.Take(source)
+	assert.False(t, ok)
+}
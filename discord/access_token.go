@@ -17,6 +17,9 @@ type AccessTokenResponse struct {
 
 	// Webhook is only present if scopes include the OAuth2ScopeWebhookIncoming
 	Webhook *IncomingWebhook `json:"webhook"`
+
+	// Guild is only present if scopes include the OAuth2ScopeBot and is the guild the bot was added to
+	Guild *RestGuild `json:"guild"`
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -57,9 +60,24 @@ type GrantType string
 const (
 	GrantTypeAuthorizationCode GrantType = "authorization_code"
 	GrantTypeRefreshToken      GrantType = "refresh_token"
+	GrantTypeClientCredentials GrantType = "client_credentials"
 )
 
 // String returns the GrantType as a string.
 func (t GrantType) String() string {
 	return string(t)
 }
+
+// TokenTypeHint tells Discord which type of token is revoked.
+type TokenTypeHint string
+
+// All TokenTypeHint(s).
+const (
+	TokenTypeHintAccessToken  TokenTypeHint = "access_token"
+	TokenTypeHintRefreshToken TokenTypeHint = "refresh_token"
+)
+
+// String returns the TokenTypeHint as a string.
+func (t TokenTypeHint) String() string {
+	return string(t)
+}
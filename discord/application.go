@@ -102,6 +102,9 @@ const (
 	OAuth2ScopeBot               OAuth2Scope = "bot"
 	OAuth2ScopeMessagesRead      OAuth2Scope = "messages.read"
 	OAuth2ScopeWebhookIncoming   OAuth2Scope = "webhook.incoming"
+
+	// OAuth2ScopeRoleConnectionsWrite allows your app to update a user's connection and metadata for the app
+	OAuth2ScopeRoleConnectionsWrite OAuth2Scope = "role_connections.write"
 )
 
 func (s OAuth2Scope) String() string {
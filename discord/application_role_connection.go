@@ -0,0 +1,42 @@
+package discord
+
+// ApplicationRoleConnectionMetadata is a metadata record of an application which admins can use to set up linked role requirements.
+// See https://discord.com/developers/docs/resources/application-role-connection-metadata for more information.
+type ApplicationRoleConnectionMetadata struct {
+	Type                     ApplicationRoleConnectionMetadataType `json:"type"`
+	Key                      string                                `json:"key"`
+	Name                     string                                `json:"name"`
+	NameLocalizations        map[Locale]string                     `json:"name_localizations,omitempty"`
+	Description              string                                `json:"description"`
+	DescriptionLocalizations map[Locale]string                     `json:"description_localizations,omitempty"`
+}
+
+// ApplicationRoleConnectionMetadataType decides how the metadata value of a user is compared to the value an admin set up.
+type ApplicationRoleConnectionMetadataType int
+
+// All ApplicationRoleConnectionMetadataType(s)
+const (
+	ApplicationRoleConnectionMetadataTypeIntegerLessThanOrEqual ApplicationRoleConnectionMetadataType = iota + 1
+	ApplicationRoleConnectionMetadataTypeIntegerGreaterThanOrEqual
+	ApplicationRoleConnectionMetadataTypeIntegerEqual
+	ApplicationRoleConnectionMetadataTypeIntegerNotEqual
+	ApplicationRoleConnectionMetadataTypeDatetimeLessThanOrEqual
+	ApplicationRoleConnectionMetadataTypeDatetimeGreaterThanOrEqual
+	ApplicationRoleConnectionMetadataTypeBooleanEqual
+	ApplicationRoleConnectionMetadataTypeBooleanNotEqual
+)
+
+// ApplicationRoleConnection is the role connection of a user to an application.
+// Metadata values are strings of integers, ISO8601 datetimes or "1" & "0" for booleans depending on the ApplicationRoleConnectionMetadataType of the key.
+type ApplicationRoleConnection struct {
+	PlatformName     *string           `json:"platform_name"`
+	PlatformUsername *string           `json:"platform_username"`
+	Metadata         map[string]string `json:"metadata"`
+}
+
+// ApplicationRoleConnectionUpdate is used to update the role connection of a user to an application.
+type ApplicationRoleConnectionUpdate struct {
+	PlatformName     *string            `json:"platform_name,omitempty"`
+	PlatformUsername *string            `json:"platform_username,omitempty"`
+	Metadata         *map[string]string `json:"metadata,omitempty"`
+}
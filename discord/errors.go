@@ -12,7 +12,11 @@ var (
 	ErrGatewayAlreadyConnected = errors.New("gateway is already connected")
 	ErrShardNotConnected       = errors.New("shard is not connected")
 	ErrShardNotFound           = errors.New("shard not found in shard manager")
+	ErrSessionStartLimit       = errors.New("not enough session starts remaining")
 	ErrGatewayCompressedData   = errors.New("disgo does not currently support compressed gateway data")
+	ErrGatewayZombied          = errors.New("gateway did not receive a heartbeat ack")
+	ErrGatewayReconnect        = errors.New("gateway requested a reconnect")
+	ErrGatewayInvalidSession   = errors.New("gateway session is invalid")
 	ErrNoHTTPServer            = errors.New("no http server configured")
 
 	ErrNoDisgoInstance = errors.New("no disgo instance injected")
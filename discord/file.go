@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
 	"net/textproto"
+	"sync"
 
 	"github.com/disgoorg/disgo/json"
 )
@@ -22,25 +24,193 @@ type MultipartBuffer struct {
 
 // PayloadWithFiles returns the given payload as multipart body with all files in it
 func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
 	buffer := &bytes.Buffer{}
 	writer := multipart.NewWriter(buffer)
-	writer.FormDataContentType()
-	defer func() {
-		_ = writer.Close()
-	}()
+	readers := make([]io.Reader, len(files))
+	for i, file := range files {
+		readers[i] = file.Reader
+	}
+	if err = writeMultipart(writer, payload, files, readers); err != nil {
+		return nil, err
+	}
+
+	return &MultipartBuffer{
+		Buffer:      buffer,
+		ContentType: writer.FormDataContentType(),
+	}, nil
+}
+
+// MultipartStream is a multipart body which is written while it is read instead of being buffered in memory first.
+type MultipartStream struct {
+	ContentType string
+
+	// ContentLength is the length of the body or -1 if the size of any file is unknown.
+	// The size is known for files from a *bytes.Buffer, *bytes.Reader, *strings.Reader, *os.File or anything else with a Len() int method.
+	ContentLength int64
 
+	boundary string
+	payload  []byte
+	files    []*File
+	readers  []io.Reader
+
+	// offsets are the start offsets of the readers which can seek
+	offsets []int64
+
+	// buffers keep everything read from the readers which can't seek through their tees, so the body can be written again
+	buffers []*bytes.Buffer
+	tees    []io.Reader
+
+	read bool
+	mu   sync.Mutex
+
+	// pipe & written belong to the last Reader which may still be written to
+	pipe    *io.PipeReader
+	written chan struct{}
+}
+
+// PayloadWithFilesStream returns the given payload as streamed multipart body with all files in it.
+// The files are read while the body is read, so they must not be closed before.
+// Files which can't seek are kept in memory while they are read, so the body can be written again when a request is retried.
+func PayloadWithFilesStream(v any, files ...*File) (*MultipartStream, error) {
 	payload, err := json.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
 
+	s := &MultipartStream{
+		boundary: multipart.NewWriter(io.Discard).Boundary(),
+		payload:  payload,
+		files:    files,
+		readers:  make([]io.Reader, len(files)),
+		offsets:  make([]int64, len(files)),
+		buffers:  make([]*bytes.Buffer, len(files)),
+		tees:     make([]io.Reader, len(files)),
+	}
+	sources := make([]io.Reader, len(files))
+	for i, file := range files {
+		reader := file.Reader
+		if buffer, ok := reader.(*bytes.Buffer); ok {
+			// a reader of the unread bytes can be rewound, the buffer itself can't
+			reader = bytes.NewReader(buffer.Bytes())
+		}
+		sources[i] = reader
+
+		if seeker, ok := reader.(io.Seeker); ok {
+			if s.offsets[i], err = seeker.Seek(0, io.SeekCurrent); err == nil {
+				s.readers[i] = reader
+				continue
+			}
+		}
+		s.buffers[i] = &bytes.Buffer{}
+		s.tees[i] = io.TeeReader(reader, s.buffers[i])
+		s.readers[i] = s.tees[i]
+	}
+
+	writer := multipart.NewWriter(io.Discard)
+	_ = writer.SetBoundary(s.boundary)
+	s.ContentType = writer.FormDataContentType()
+	s.ContentLength = s.contentLength(sources)
+	return s, nil
+}
+
+// contentLength returns the length of the body by writing it without the files and adding the sizes of the given readers.
+func (s *MultipartStream) contentLength(readers []io.Reader) int64 {
+	var filesLength int64
+	empty := make([]io.Reader, len(readers))
+	for i, reader := range readers {
+		size := readerSize(reader)
+		if size < 0 {
+			return -1
+		}
+		filesLength += size
+		empty[i] = bytes.NewReader(nil)
+	}
+
+	counter := &countingWriter{}
+	writer := multipart.NewWriter(counter)
+	_ = writer.SetBoundary(s.boundary)
+	if err := writeMultipart(writer, s.payload, s.files, empty); err != nil {
+		return -1
+	}
+	return counter.n + filesLength
+}
+
+// Reader returns an io.ReadCloser of the body which is written in the background. Closing it before it was read completely stops writing the body.
+// Every call returns the whole body again, so it can be used to retry requests.
+func (s *MultipartStream) Reader() (io.ReadCloser, error) {
+	if err := s.rewind(); err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	written := make(chan struct{})
+	s.mu.Lock()
+	s.pipe, s.written = pr, written
+	s.mu.Unlock()
+	go func() {
+		defer close(written)
+		_ = pw.CloseWithError(s.write(pw))
+	}()
+	return pr, nil
+}
+
+// WriteTo writes the body to the given io.Writer. Every call writes the whole body again.
+func (s *MultipartStream) WriteTo(w io.Writer) (int64, error) {
+	if err := s.rewind(); err != nil {
+		return 0, err
+	}
+	counter := &countingWriter{w: w}
+	err := s.write(counter)
+	return counter.n, err
+}
+
+func (s *MultipartStream) rewind() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.read {
+		s.read = true
+		return nil
+	}
+	if s.pipe != nil {
+		// stop writing the last Reader before rewinding the files it reads from
+		_ = s.pipe.Close()
+		<-s.written
+		s.pipe, s.written = nil, nil
+	}
+	for i, reader := range s.readers {
+		if s.buffers[i] != nil {
+			// replay what was read already and continue with the rest, which is buffered as well while it is read
+			s.readers[i] = io.MultiReader(bytes.NewReader(s.buffers[i].Bytes()), s.tees[i])
+			continue
+		}
+		if _, err := reader.(io.Seeker).Seek(s.offsets[i], io.SeekStart); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MultipartStream) write(w io.Writer) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(s.boundary); err != nil {
+		return err
+	}
+	return writeMultipart(writer, s.payload, s.files, s.readers)
+}
+
+// writeMultipart writes the payload & files with the content of the given readers and closes the multipart.Writer.
+func writeMultipart(writer *multipart.Writer, payload []byte, files []*File, readers []io.Reader) error {
 	part, err := writer.CreatePart(partHeader(`form-data; name="payload_json"`, "application/json"))
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if _, err = part.Write(payload); err != nil {
-		return nil, err
+		return err
 	}
 
 	for i, file := range files {
@@ -52,18 +222,52 @@ func PayloadWithFiles(v any, files ...*File) (*MultipartBuffer, error) {
 		}
 		part, err = writer.CreatePart(partHeader(fmt.Sprintf(`form-data; name="files[%d]"; filename="%s"`, i, name), "application/octet-stream"))
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if _, err = io.Copy(part, file.Reader); err != nil {
-			return nil, err
+		if _, err = io.Copy(part, readers[i]); err != nil {
+			return err
 		}
 	}
+	return writer.Close()
+}
 
-	return &MultipartBuffer{
-		Buffer:      buffer,
-		ContentType: writer.FormDataContentType(),
-	}, nil
+// readerSize returns the number of bytes left in the io.Reader or -1 if it is unknown.
+func readerSize(reader io.Reader) int64 {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+
+	case interface {
+		Stat() (fs.FileInfo, error)
+		io.Seeker
+	}:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return info.Size() - offset
+	}
+	return -1
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	if w.w == nil {
+		w.n += int64(len(p))
+		return len(p), nil
+	}
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
 }
 
 func partHeader(contentDisposition string, contentType string) textproto.MIMEHeader {
@@ -0,0 +1,57 @@
+package discord
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultipartStream(t *testing.T) {
+	tests := []struct {
+		name          string
+		reader        func() io.Reader
+		contentLength bool
+	}{
+		{name: "bytes.Buffer", reader: func() io.Reader { return bytes.NewBufferString("file content") }, contentLength: true},
+		{name: "strings.Reader", reader: func() io.Reader { return strings.NewReader("file content") }, contentLength: true},
+		{name: "unknown", reader: func() io.Reader { return io.MultiReader(strings.NewReader("file content")) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := PayloadWithFilesStream(MessageCreate{Content: "content"}, NewFile("file.txt", "", tt.reader()))
+			assert.NoError(t, err)
+
+			r, err := stream.Reader()
+			assert.NoError(t, err)
+			body, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			assert.Contains(t, string(body), "file content")
+			assert.Contains(t, stream.ContentType, "multipart/form-data")
+
+			if tt.contentLength {
+				assert.Equal(t, int64(len(body)), stream.ContentLength)
+			} else {
+				assert.Equal(t, int64(-1), stream.ContentLength)
+			}
+
+			buff := &bytes.Buffer{}
+			_, err = stream.WriteTo(buff)
+			assert.NoError(t, err)
+			assert.Equal(t, body, buff.Bytes())
+
+			// a body which was read until the middle of the file is written again completely
+			r, err = stream.Reader()
+			assert.NoError(t, err)
+			_, err = io.ReadFull(r, make([]byte, bytes.Index(body, []byte("file content"))+4))
+			assert.NoError(t, err)
+			assert.NoError(t, r.Close())
+			buff.Reset()
+			_, err = stream.WriteTo(buff)
+			assert.NoError(t, err)
+			assert.Equal(t, body, buff.Bytes())
+		})
+	}
+}
@@ -22,7 +22,7 @@ func (m MessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return PayloadWithFilesStream(m, m.Files...)
 	}
 	return m, nil
 }
@@ -35,7 +35,7 @@ func (m MessageUpdate) ToResponseBody(response InteractionResponse) (any, error)
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(response, m.Files...)
+		return PayloadWithFilesStream(response, m.Files...)
 	}
 	return response, nil
 }
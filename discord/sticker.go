@@ -58,7 +58,7 @@ type StickerCreate struct {
 // ToBody returns the MessageCreate ready for body
 func (c StickerCreate) ToBody() (any, error) {
 	if c.File != nil {
-		return PayloadWithFiles(c, c.File)
+		return PayloadWithFilesStream(c, c.File)
 	}
 	return c, nil
 }
@@ -19,7 +19,7 @@ func (m WebhookMessageUpdate) ToBody() (any, error) {
 			}
 			*m.Attachments = append(*m.Attachments, attachmentCreate)
 		}
-		return PayloadWithFiles(m, m.Files...)
+		return PayloadWithFilesStream(m, m.Files...)
 	}
 	return m, nil
 }
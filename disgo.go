@@ -43,6 +43,30 @@
 // OAuth2
 //
 // Package oauth2 provides a high level client interface for interacting with Discord oauth2.
+//
+// Modal
+//
+// Package modal provides a helper to open a modal and wait for its validated submission.
+//
+// Component
+//
+// Package component provides stateful sessions for interactive messages with buttons & select menus.
+//
+// Widget
+//
+// Package widget provides ready-made interactive messages like an embed paginator and a confirmation prompt.
+//
+// TextCommand
+//
+// Package textcommand provides a framework for prefixed message based commands.
+//
+// Cooldown
+//
+// Package cooldown provides rate limiting for commands by user, member, channel, guild or globally.
+//
+// DisgoTest
+//
+// Package disgotest provides a fake Discord REST API to test code using the Rest package end-to-end.
 package disgo
 
 import (
@@ -0,0 +1,62 @@
+package disgotest
+
+import (
+	"net/http"
+)
+
+// JSON error codes returned by Discord (https://discord.com/developers/docs/topics/opcodes-and-status-codes#json-json-error-codes)
+const (
+	codeGeneral         = 0
+	codeUnknownChannel  = 10003
+	codeUnknownGuild    = 10004
+	codeUnknownMember   = 10007
+	codeUnknownMessage  = 10008
+	codeUnknownUser     = 10013
+	codeUnknownEmoji    = 10014
+	codeCannotEditOther = 50005
+	codeEmptyMessage    = 50006
+	codeInvalidFormBody = 50035
+	codeInvalidJSON     = 50109
+)
+
+// apiError is the error envelope Discord responds with.
+type apiError struct {
+	status  int
+	Code    int            `json:"code"`
+	Message string         `json:"message"`
+	Errors  map[string]any `json:"errors,omitempty"`
+}
+
+func newAPIError(status int, code int, message string) *apiError {
+	return &apiError{
+		status:  status,
+		Code:    code,
+		Message: message,
+	}
+}
+
+var (
+	errUnauthorized            = newAPIError(http.StatusUnauthorized, codeGeneral, "401: Unauthorized")
+	errNotFound                = newAPIError(http.StatusNotFound, codeGeneral, "404: Not Found")
+	errMethodNotAllowed        = newAPIError(http.StatusMethodNotAllowed, codeGeneral, "405: Method Not Allowed")
+	errUnknownChannel          = newAPIError(http.StatusNotFound, codeUnknownChannel, "Unknown Channel")
+	errUnknownGuild            = newAPIError(http.StatusNotFound, codeUnknownGuild, "Unknown Guild")
+	errUnknownMember           = newAPIError(http.StatusNotFound, codeUnknownMember, "Unknown Member")
+	errUnknownMessage          = newAPIError(http.StatusNotFound, codeUnknownMessage, "Unknown Message")
+	errUnknownUser             = newAPIError(http.StatusNotFound, codeUnknownUser, "Unknown User")
+	errUnknownEmoji            = newAPIError(http.StatusBadRequest, codeUnknownEmoji, "Unknown Emoji")
+	errCannotEditOthersMessage = newAPIError(http.StatusForbidden, codeCannotEditOther, "Cannot edit a message authored by another user")
+	errEmptyMessage            = newAPIError(http.StatusBadRequest, codeEmptyMessage, "Cannot send an empty message")
+	errInvalidJSON             = newAPIError(http.StatusBadRequest, codeInvalidJSON, "The request body contains invalid JSON.")
+)
+
+// errInvalidFormBody returns the error Discord responds with if a field of the request body is invalid.
+func errInvalidFormBody(field string, code string, message string) *apiError {
+	err := newAPIError(http.StatusBadRequest, codeInvalidFormBody, "Invalid Form Body")
+	err.Errors = map[string]any{
+		field: map[string]any{
+			"_errors": []map[string]string{{"code": code, "message": message}},
+		},
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package disgotest
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/rest/route"
+)
+
+// rateLimiter hands out requests per bucket & major parameters like Discord does and sets the matching headers (https://discord.com/developers/docs/topics/rate-limits#header-format).
+type rateLimiter struct {
+	limit      int
+	resetAfter time.Duration
+
+	mu      sync.Mutex
+	buckets map[string]*rateLimitBucket
+}
+
+type rateLimitBucket struct {
+	remaining int
+	reset     time.Time
+}
+
+func newRateLimiter(limit int, resetAfter time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:      limit,
+		resetAfter: resetAfter,
+		buckets:    map[string]*rateLimitBucket{},
+	}
+}
+
+// bucketID returns the X-RateLimit-Bucket of the given route.APIRoute.
+func bucketID(apiRoute *route.APIRoute) string {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(apiRoute.Method().String() + "+" + apiRoute.Path()))
+	return fmt.Sprintf("%016x", hash.Sum64())
+}
+
+// take takes a request from the bucket of the given route.APIRoute & major parameters and sets the rate limit headers.
+// It returns false & the time to wait if the bucket is exhausted.
+func (l *rateLimiter) take(header http.Header, apiRoute *route.APIRoute, majorParams string) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+	id := bucketID(apiRoute)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	b, ok := l.buckets[id+":"+majorParams]
+	if !ok || !now.Before(b.reset) {
+		b = &rateLimitBucket{
+			remaining: l.limit,
+			reset:     now.Add(l.resetAfter),
+		}
+		l.buckets[id+":"+majorParams] = b
+	}
+
+	resetAfter := b.reset.Sub(now)
+	header.Set("X-RateLimit-Bucket", id)
+	header.Set("X-RateLimit-Limit", strconv.Itoa(l.limit))
+	header.Set("X-RateLimit-Reset", strconv.FormatFloat(float64(b.reset.UnixNano())/float64(time.Second), 'f', 3, 64))
+	header.Set("X-RateLimit-Reset-After", strconv.FormatFloat(resetAfter.Seconds(), 'f', 3, 64))
+
+	if b.remaining == 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Scope", "user")
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(resetAfter.Seconds()))))
+		return false, resetAfter
+	}
+	b.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(b.remaining))
+	return true, 0
+}
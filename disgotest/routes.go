@@ -0,0 +1,791 @@
+package disgotest
+
+import (
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest/route"
+)
+
+// routeHandler handles requests of a route.APIRoute. It returns the status code & the body to respond with.
+type routeHandler struct {
+	route  *route.APIRoute
+	handle func(s *serverImpl, rq *request) (int, any)
+}
+
+// routeHandlers are all routes the Server implements.
+var routeHandlers = []routeHandler{
+	{route.GetGateway, handleGetGateway},
+	{route.GetGatewayBot, handleGetGatewayBot},
+
+	{route.GetCurrentUser, handleGetCurrentUser},
+	{route.GetUser, handleGetUser},
+
+	{route.GetGuild, handleGetGuild},
+	{route.GetGuildChannels, handleGetGuildChannels},
+	{route.CreateGuildChannel, handleCreateGuildChannel},
+
+	{route.GetChannel, handleGetChannel},
+	{route.UpdateChannel, handleUpdateChannel},
+	{route.DeleteChannel, handleDeleteChannel},
+	{route.SendTyping, handleSendTyping},
+
+	{route.GetMessages, handleGetMessages},
+	{route.GetMessage, handleGetMessage},
+	{route.CreateMessage, handleCreateMessage},
+	{route.UpdateMessage, handleUpdateMessage},
+	{route.DeleteMessage, handleDeleteMessage},
+	{route.BulkDeleteMessages, handleBulkDeleteMessages},
+	{route.GetPinnedMessages, handleGetPinnedMessages},
+	{route.PinMessage, handlePinMessage(true)},
+	{route.UnpinMessage, handlePinMessage(false)},
+	{route.AddReaction, handleAddReaction},
+	{route.RemoveOwnReaction, handleRemoveOwnReaction},
+	{route.RemoveAllReactions, handleRemoveAllReactions},
+
+	{route.GetMember, handleGetMember},
+	{route.GetMembers, handleGetMembers},
+	{route.SearchMembers, handleSearchMembers},
+	{route.UpdateMember, handleUpdateMember},
+	{route.RemoveMember, handleRemoveMember},
+	{route.AddMemberRole, handleMemberRole(true)},
+	{route.RemoveMemberRole, handleMemberRole(false)},
+}
+
+// matchRoute returns the routeHandler & path parameters of the given request.
+// If several routes match, the one with the most static path segments wins, so /users/@me is preferred over /users/{user.id}.
+func matchRoute(method string, path string) (*routeHandler, map[string]string, *apiError) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var (
+		best        *routeHandler
+		bestParams  map[string]string
+		bestStatic  = -1
+		pathMatched bool
+	)
+	for i := range routeHandlers {
+		h := &routeHandlers[i]
+		params, static, ok := matchPath(h.route.Path(), segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if h.route.Method().String() != method || static <= bestStatic {
+			continue
+		}
+		best, bestParams, bestStatic = h, params, static
+	}
+	if best != nil {
+		return best, bestParams, nil
+	}
+	if pathMatched {
+		return nil, nil, errMethodNotAllowed
+	}
+	return nil, nil, errNotFound
+}
+
+// matchPath matches the path segments against the route path and returns the path parameters & the number of static segments.
+func matchPath(routePath string, segments []string) (map[string]string, int, bool) {
+	routeSegments := strings.Split(strings.Trim(routePath, "/"), "/")
+	if len(routeSegments) != len(segments) {
+		return nil, 0, false
+	}
+	params := map[string]string{}
+	static := 0
+	for i, routeSegment := range routeSegments {
+		if strings.HasPrefix(routeSegment, "{") && strings.HasSuffix(routeSegment, "}") {
+			params[routeSegment[1:len(routeSegment)-1]] = segments[i]
+			continue
+		}
+		if routeSegment != segments[i] {
+			return nil, 0, false
+		}
+		static++
+	}
+	return params, static, true
+}
+
+func respondOK(body any) (int, any) {
+	return http.StatusOK, body
+}
+
+func respondNoContent() (int, any) {
+	return http.StatusNoContent, nil
+}
+
+func respondError(err *apiError) (int, any) {
+	return err.status, err
+}
+
+// queryInt returns the int query parameter or the default value. It fails if the value is not in [min, max].
+func queryInt(rq *request, name string, defaultValue int, min int, max int) (int, *apiError) {
+	raw := rq.URL.Query().Get(name)
+	if raw == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errInvalidFormBody(name, "NUMBER_TYPE_COERCE", "Value \""+raw+"\" is not int.")
+	}
+	if value < min {
+		return 0, errInvalidFormBody(name, "NUMBER_TYPE_MIN", "int value should be greater than or equal to "+strconv.Itoa(min)+".")
+	}
+	if value > max {
+		return 0, errInvalidFormBody(name, "NUMBER_TYPE_MAX", "int value should be less than or equal to "+strconv.Itoa(max)+".")
+	}
+	return value, nil
+}
+
+// querySnowflake returns the snowflake.ID query parameter or 0.
+func querySnowflake(rq *request, name string) snowflake.ID {
+	id, _ := snowflake.Parse(rq.URL.Query().Get(name))
+	return id
+}
+
+func handleGetGateway(_ *serverImpl, _ *request) (int, any) {
+	return respondOK(discord.Gateway{URL: "wss://gateway.discord.gg"})
+}
+
+func handleGetGatewayBot(_ *serverImpl, _ *request) (int, any) {
+	return respondOK(discord.GatewayBot{
+		URL:    "wss://gateway.discord.gg",
+		Shards: 1,
+		SessionStartLimit: discord.SessionStartLimit{
+			Total:          1000,
+			Remaining:      1000,
+			ResetAfter:     int(24 * time.Hour / time.Millisecond),
+			MaxConcurrency: 1,
+		},
+	})
+}
+
+func handleGetCurrentUser(s *serverImpl, _ *request) (int, any) {
+	return respondOK(s.store.users[s.config.BotUser.ID])
+}
+
+func handleGetUser(s *serverImpl, rq *request) (int, any) {
+	user, found := s.store.users[rq.id("user.id")]
+	if !found {
+		return respondError(errUnknownUser)
+	}
+	return respondOK(user)
+}
+
+func handleGetGuild(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	guild, found := s.store.guilds[guildID]
+	if !found {
+		return respondError(errUnknownGuild)
+	}
+	guild = guild.copy()
+	if rq.URL.Query().Get("with_counts") == "true" {
+		guild.set("approximate_member_count", len(s.store.members[guildID]))
+		guild.set("approximate_presence_count", 0)
+	}
+	return respondOK(guild)
+}
+
+func handleGetGuildChannels(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	channels := s.store.guildChannels(guildID)
+	if channels == nil {
+		channels = []object{}
+	}
+	return respondOK(channels)
+}
+
+func handleCreateGuildChannel(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	channelCreate, _, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+	var name string
+	if !channelCreate.get("name", &name) || name == "" {
+		return respondError(errInvalidFormBody("name", "BASE_TYPE_REQUIRED", "This field is required"))
+	}
+	if len(name) > 100 {
+		return respondError(errInvalidFormBody("name", "BASE_TYPE_BAD_LENGTH", "Must be between 1 and 100 in length."))
+	}
+	return http.StatusCreated, s.createGuildChannel(guildID, channelCreate)
+}
+
+// createGuildChannel creates a channel from the given create payload. The store needs to be locked.
+func (s *serverImpl) createGuildChannel(guildID snowflake.ID, channel object) object {
+	channel = channel.copy()
+	channel.set("id", s.store.newID())
+	channel.set("guild_id", guildID)
+	if !channel.has("type") {
+		channel.set("type", discord.ChannelTypeGuildText)
+	}
+	if !channel.has("position") {
+		channel.set("position", len(s.store.guildChannels(guildID)))
+	}
+	if !channel.has("permission_overwrites") {
+		channel.set("permission_overwrites", []any{})
+	}
+	s.store.channels[channel.id("id")] = channel
+	return channel.copy()
+}
+
+func handleGetChannel(s *serverImpl, rq *request) (int, any) {
+	channel, found := s.store.channels[rq.id("channel.id")]
+	if !found {
+		return respondError(errUnknownChannel)
+	}
+	return respondOK(channel)
+}
+
+func handleUpdateChannel(s *serverImpl, rq *request) (int, any) {
+	channel, found := s.store.channels[rq.id("channel.id")]
+	if !found {
+		return respondError(errUnknownChannel)
+	}
+	channelUpdate, _, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+	// the id, type & guild of a channel can't be changed
+	delete(channelUpdate, "id")
+	delete(channelUpdate, "type")
+	delete(channelUpdate, "guild_id")
+	channel.merge(channelUpdate)
+	return respondOK(channel)
+}
+
+func handleDeleteChannel(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	channel, found := s.store.channels[channelID]
+	if !found {
+		return respondError(errUnknownChannel)
+	}
+	delete(s.store.channels, channelID)
+	delete(s.store.messages, channelID)
+	return respondOK(channel)
+}
+
+func handleSendTyping(s *serverImpl, rq *request) (int, any) {
+	if _, found := s.store.channels[rq.id("channel.id")]; !found {
+		return respondError(errUnknownChannel)
+	}
+	return respondNoContent()
+}
+
+func handleGetMessages(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	limit, err := queryInt(rq, "limit", 50, 1, 100)
+	if err != nil {
+		return respondError(err)
+	}
+	messages := s.store.messages[channelID]
+	index := func(id snowflake.ID) int {
+		return sort.Search(len(messages), func(i int) bool {
+			return messages[i].id("id") >= id
+		})
+	}
+
+	// messages are sorted from oldest to newest, Discord responds from newest to oldest
+	start, end := 0, len(messages)
+	if around := querySnowflake(rq, "around"); around != 0 {
+		i := index(around)
+		start = i - limit/2
+		if start < 0 {
+			start = 0
+		}
+		end = start + limit
+	} else if after := querySnowflake(rq, "after"); after != 0 {
+		start = index(after + 1)
+		end = start + limit
+	} else {
+		if before := querySnowflake(rq, "before"); before != 0 {
+			end = index(before)
+		}
+		start = end - limit
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > len(messages) {
+		end = len(messages)
+	}
+
+	page := make([]object, 0, end-start)
+	for i := end - 1; i >= start; i-- {
+		page = append(page, messages[i].copy())
+	}
+	return respondOK(page)
+}
+
+func handleGetMessage(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	_, message, found := s.store.message(channelID, rq.id("message.id"))
+	if !found {
+		return respondError(errUnknownMessage)
+	}
+	return respondOK(message)
+}
+
+func handleCreateMessage(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	channel, found := s.store.channels[channelID]
+	if !found {
+		return respondError(errUnknownChannel)
+	}
+	messageCreate, files, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+
+	var content string
+	messageCreate.get("content", &content)
+	if len([]rune(content)) > 2000 {
+		return respondError(errInvalidFormBody("content", "BASE_TYPE_MAX_LENGTH", "Must be 2000 or fewer in length."))
+	}
+	if content == "" && len(files) == 0 && !nonEmpty(messageCreate, "embeds") && !nonEmpty(messageCreate, "components") && !nonEmpty(messageCreate, "sticker_ids") {
+		return respondError(errEmptyMessage)
+	}
+
+	messageID := s.store.newID()
+	message := object{}
+	message.set("id", messageID)
+	message.set("channel_id", channelID)
+	if guildID := channel.id("guild_id"); guildID != 0 {
+		message.set("guild_id", guildID)
+	}
+	message.set("author", s.store.users[s.config.BotUser.ID])
+	message.set("content", content)
+	message.set("timestamp", messageID.Time())
+	message.set("edited_timestamp", nil)
+	message.set("mention_everyone", false)
+	message.set("mentions", []any{})
+	message.set("mention_roles", []any{})
+	message.set("pinned", false)
+	message.set("type", discord.MessageTypeDefault)
+	for _, key := range []string{"tts", "embeds", "components", "flags", "message_reference"} {
+		if value, ok := messageCreate[key]; ok {
+			message[key] = value
+		}
+	}
+	if !message.has("tts") {
+		message.set("tts", false)
+	}
+	if !message.has("embeds") {
+		message.set("embeds", []any{})
+	}
+	if message.has("message_reference") {
+		message.set("type", discord.MessageTypeReply)
+	}
+	message.set("attachments", s.attachments(channelID, messageCreate, files))
+
+	s.store.putMessage(message)
+	return respondOK(message)
+}
+
+// attachments returns the discord.Attachment(s) of the uploaded files. The store needs to be locked.
+func (s *serverImpl) attachments(channelID snowflake.ID, payload object, files map[int]*multipart.FileHeader) []discord.Attachment {
+	var attachmentCreates []discord.AttachmentCreate
+	payload.get("attachments", &attachmentCreates)
+	descriptions := map[int]string{}
+	for _, attachmentCreate := range attachmentCreates {
+		descriptions[attachmentCreate.ID] = attachmentCreate.Description
+	}
+
+	indices := make([]int, 0, len(files))
+	for i := range files {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	attachments := make([]discord.Attachment, 0, len(files))
+	for _, i := range indices {
+		file := files[i]
+		id := s.store.newID()
+		url := "https://cdn.discordapp.com/attachments/" + channelID.String() + "/" + id.String() + "/" + file.Filename
+		attachment := discord.Attachment{
+			ID:       id,
+			Filename: file.Filename,
+			Size:     int(file.Size),
+			URL:      url,
+			ProxyURL: strings.Replace(url, "cdn.discordapp.com", "media.discordapp.net", 1),
+		}
+		if description, ok := descriptions[i]; ok && description != "" {
+			attachment.Description = &description
+		}
+		if contentType := file.Header.Get("Content-Type"); contentType != "" {
+			attachment.ContentType = &contentType
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
+// nonEmpty returns whether the given key contains a non-empty array.
+func nonEmpty(o object, key string) bool {
+	var values []any
+	return o.get(key, &values) && len(values) > 0
+}
+
+func handleUpdateMessage(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	_, message, found := s.store.message(channelID, rq.id("message.id"))
+	if !found {
+		return respondError(errUnknownMessage)
+	}
+	var author object
+	message.get("author", &author)
+	if author.id("id") != s.config.BotUser.ID {
+		return respondError(errCannotEditOthersMessage)
+	}
+	messageUpdate, files, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+	var content string
+	if messageUpdate.get("content", &content) && len([]rune(content)) > 2000 {
+		return respondError(errInvalidFormBody("content", "BASE_TYPE_MAX_LENGTH", "Must be 2000 or fewer in length."))
+	}
+
+	message = message.copy()
+	for _, key := range []string{"content", "embeds", "components", "flags"} {
+		if value, ok := messageUpdate[key]; ok {
+			message[key] = value
+		}
+	}
+	if messageUpdate.has("attachments") || len(files) > 0 {
+		// attachments which are not kept are removed, new files are appended
+		var kept []struct {
+			ID snowflake.ID `json:"id"`
+		}
+		messageUpdate.get("attachments", &kept)
+		keep := map[snowflake.ID]struct{}{}
+		for _, attachment := range kept {
+			keep[attachment.ID] = struct{}{}
+		}
+		var oldAttachments, attachments []discord.Attachment
+		message.get("attachments", &oldAttachments)
+		for _, attachment := range oldAttachments {
+			if _, ok := keep[attachment.ID]; ok {
+				attachments = append(attachments, attachment)
+			}
+		}
+		attachments = append(attachments, s.attachments(channelID, messageUpdate, files)...)
+		if attachments == nil {
+			attachments = []discord.Attachment{}
+		}
+		message.set("attachments", attachments)
+	}
+	message.set("edited_timestamp", time.Now())
+	s.store.putMessage(message)
+	return respondOK(message)
+}
+
+func handleDeleteMessage(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	if !s.store.deleteMessage(channelID, rq.id("message.id")) {
+		return respondError(errUnknownMessage)
+	}
+	return respondNoContent()
+}
+
+func handleBulkDeleteMessages(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	bulkDelete, _, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+	var messageIDs []snowflake.ID
+	bulkDelete.get("messages", &messageIDs)
+	if len(messageIDs) < 2 {
+		return respondError(errInvalidFormBody("messages", "BASE_TYPE_MIN_LENGTH", "Must be 2 or more in length."))
+	}
+	if len(messageIDs) > 100 {
+		return respondError(errInvalidFormBody("messages", "BASE_TYPE_MAX_LENGTH", "Must be 100 or fewer in length."))
+	}
+	for _, messageID := range messageIDs {
+		s.store.deleteMessage(channelID, messageID)
+	}
+	return respondNoContent()
+}
+
+func handleGetPinnedMessages(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	messages := s.store.messages[channelID]
+	pinned := []object{}
+	for i := len(messages) - 1; i >= 0; i-- {
+		var isPinned bool
+		if messages[i].get("pinned", &isPinned) && isPinned {
+			pinned = append(pinned, messages[i].copy())
+		}
+	}
+	return respondOK(pinned)
+}
+
+func handlePinMessage(pinned bool) func(s *serverImpl, rq *request) (int, any) {
+	return func(s *serverImpl, rq *request) (int, any) {
+		channelID := rq.id("channel.id")
+		if _, found := s.store.channels[channelID]; !found {
+			return respondError(errUnknownChannel)
+		}
+		_, message, found := s.store.message(channelID, rq.id("message.id"))
+		if !found {
+			return respondError(errUnknownMessage)
+		}
+		message = message.copy()
+		message.set("pinned", pinned)
+		s.store.putMessage(message)
+		return respondNoContent()
+	}
+}
+
+// reactionEmoji parses the emoji path parameter, which is either a unicode emoji or name:id of a custom emoji.
+func reactionEmoji(rq *request) (discord.Emoji, *apiError) {
+	emoji := rq.params["emoji"]
+	if emoji == "" {
+		return discord.Emoji{}, errUnknownEmoji
+	}
+	if name, rawID, isCustom := strings.Cut(emoji, ":"); isCustom {
+		id, err := snowflake.Parse(rawID)
+		if err != nil {
+			return discord.Emoji{}, errUnknownEmoji
+		}
+		return discord.Emoji{ID: id, Name: name}, nil
+	}
+	return discord.Emoji{Name: emoji}, nil
+}
+
+// updateReaction adds or removes the reaction of the bot on the given message.
+func updateReaction(s *serverImpl, rq *request, add bool) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	_, message, found := s.store.message(channelID, rq.id("message.id"))
+	if !found {
+		return respondError(errUnknownMessage)
+	}
+	emoji, err := reactionEmoji(rq)
+	if err != nil {
+		return respondError(err)
+	}
+
+	var reactions []discord.MessageReaction
+	message.get("reactions", &reactions)
+	i := -1
+	for j, reaction := range reactions {
+		// custom emojis are identified by their id, unicode emojis by their name
+		if reaction.Emoji.ID == emoji.ID && (emoji.ID != 0 || reaction.Emoji.Name == emoji.Name) {
+			i = j
+			break
+		}
+	}
+	switch {
+	case add && i == -1:
+		reactions = append(reactions, discord.MessageReaction{Count: 1, Me: true, Emoji: emoji})
+	case add && !reactions[i].Me:
+		reactions[i].Count++
+		reactions[i].Me = true
+	case !add && i != -1 && reactions[i].Me:
+		reactions[i].Count--
+		reactions[i].Me = false
+		if reactions[i].Count == 0 {
+			reactions = append(reactions[:i], reactions[i+1:]...)
+		}
+	}
+
+	message = message.copy()
+	if len(reactions) == 0 {
+		delete(message, "reactions")
+	} else {
+		message.set("reactions", reactions)
+	}
+	s.store.putMessage(message)
+	return respondNoContent()
+}
+
+func handleAddReaction(s *serverImpl, rq *request) (int, any) {
+	return updateReaction(s, rq, true)
+}
+
+func handleRemoveOwnReaction(s *serverImpl, rq *request) (int, any) {
+	return updateReaction(s, rq, false)
+}
+
+func handleRemoveAllReactions(s *serverImpl, rq *request) (int, any) {
+	channelID := rq.id("channel.id")
+	if _, found := s.store.channels[channelID]; !found {
+		return respondError(errUnknownChannel)
+	}
+	_, message, found := s.store.message(channelID, rq.id("message.id"))
+	if !found {
+		return respondError(errUnknownMessage)
+	}
+	message = message.copy()
+	delete(message, "reactions")
+	s.store.putMessage(message)
+	return respondNoContent()
+}
+
+func handleGetMember(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	member, found := s.store.members[guildID][rq.id("user.id")]
+	if !found {
+		return respondError(errUnknownMember)
+	}
+	return respondOK(member)
+}
+
+func handleGetMembers(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	limit, err := queryInt(rq, "limit", 1, 1, 1000)
+	if err != nil {
+		return respondError(err)
+	}
+	after := querySnowflake(rq, "after")
+
+	members := []object{}
+	for _, member := range s.store.sortedMembers(guildID) {
+		if len(members) == limit {
+			break
+		}
+		if memberUserID(member) > after {
+			members = append(members, member)
+		}
+	}
+	return respondOK(members)
+}
+
+func handleSearchMembers(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	query := strings.ToLower(rq.URL.Query().Get("query"))
+	if query == "" {
+		return respondError(errInvalidFormBody("query", "BASE_TYPE_REQUIRED", "This field is required"))
+	}
+	limit, err := queryInt(rq, "limit", 1, 1, 1000)
+	if err != nil {
+		return respondError(err)
+	}
+
+	members := []object{}
+	for _, member := range s.store.sortedMembers(guildID) {
+		if len(members) == limit {
+			break
+		}
+		var (
+			user object
+			name string
+			nick string
+		)
+		member.get("user", &user)
+		user.get("username", &name)
+		member.get("nick", &nick)
+		if strings.HasPrefix(strings.ToLower(name), query) || strings.HasPrefix(strings.ToLower(nick), query) {
+			members = append(members, member)
+		}
+	}
+	return respondOK(members)
+}
+
+func handleUpdateMember(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	member, found := s.store.members[guildID][rq.id("user.id")]
+	if !found {
+		return respondError(errUnknownMember)
+	}
+	memberUpdate, _, err := rq.object()
+	if err != nil {
+		return respondError(err)
+	}
+	member = member.copy()
+	for _, key := range []string{"nick", "roles", "mute", "deaf", "communication_disabled_until"} {
+		if value, ok := memberUpdate[key]; ok {
+			member[key] = value
+		}
+	}
+	s.store.putMember(guildID, member)
+	return respondOK(member)
+}
+
+func handleRemoveMember(s *serverImpl, rq *request) (int, any) {
+	guildID := rq.id("guild.id")
+	if _, found := s.store.guilds[guildID]; !found {
+		return respondError(errUnknownGuild)
+	}
+	userID := rq.id("user.id")
+	if _, found := s.store.members[guildID][userID]; !found {
+		return respondError(errUnknownMember)
+	}
+	delete(s.store.members[guildID], userID)
+	return respondNoContent()
+}
+
+func handleMemberRole(add bool) func(s *serverImpl, rq *request) (int, any) {
+	return func(s *serverImpl, rq *request) (int, any) {
+		guildID := rq.id("guild.id")
+		if _, found := s.store.guilds[guildID]; !found {
+			return respondError(errUnknownGuild)
+		}
+		member, found := s.store.members[guildID][rq.id("user.id")]
+		if !found {
+			return respondError(errUnknownMember)
+		}
+		roleID := rq.id("role.id")
+
+		var roleIDs []snowflake.ID
+		member.get("roles", &roleIDs)
+		newRoleIDs := []snowflake.ID{}
+		for _, id := range roleIDs {
+			if id != roleID {
+				newRoleIDs = append(newRoleIDs, id)
+			}
+		}
+		if add {
+			newRoleIDs = append(newRoleIDs, roleID)
+		}
+		member = member.copy()
+		member.set("roles", newRoleIDs)
+		s.store.putMember(guildID, member)
+		return respondNoContent()
+	}
+}
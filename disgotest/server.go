@@ -0,0 +1,413 @@
+// Package disgotest provides a fake Discord REST API to test code using the rest package end-to-end.
+package disgotest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+)
+
+// Server is a fake Discord REST API running on a local httptest.Server.
+// It implements the common routes of guilds, channels, messages, members & users against an in-memory model,
+// responds with Discord's rate limit headers & error envelopes and lets you test code using rest.Rest end-to-end:
+//
+//	server := disgotest.New()
+//	defer server.Close()
+//	client, _ := disgo.New(token, bot.WithRestClientConfigOpts(server.RestConfigOpts()...))
+type Server interface {
+	// Logger returns the logger of the Server.
+	Logger() log.Logger
+
+	// URL returns the base URL of the API, which is used instead of route.API.
+	URL() string
+
+	// HTTPClient returns a http.Client which is configured to talk to the Server.
+	HTTPClient() *http.Client
+
+	// RestConfigOpts returns the rest.ConfigOpt(s) which point a rest.Client at the Server.
+	RestConfigOpts() []rest.ConfigOpt
+
+	// Close shuts down the Server.
+	Close()
+
+	// BotUser returns the discord.User of the bot.
+	BotUser() discord.User
+
+	// AddUser adds the discord.User.
+	AddUser(user discord.User)
+
+	// AddGuild adds the discord.Guild. If it has no ID, a new one is assigned.
+	AddGuild(guild discord.Guild) discord.Guild
+
+	// AddChannel adds the discord.GuildChannel.
+	AddChannel(channel discord.GuildChannel)
+
+	// CreateGuildChannel creates a new discord.GuildChannel in the given guild like the CreateGuildChannel route does.
+	CreateGuildChannel(guildID snowflake.ID, channelCreate discord.GuildChannelCreate) discord.GuildChannel
+
+	// AddMember adds the discord.Member to the guild of discord.Member.GuildID.
+	AddMember(member discord.Member)
+
+	// AddMessage adds the discord.Message. If it has no ID, a new one & the current time are assigned.
+	AddMessage(message discord.Message) discord.Message
+
+	// Guild returns the discord.Guild with the given ID.
+	Guild(guildID snowflake.ID) (discord.Guild, bool)
+
+	// Channel returns the discord.GuildChannel with the given ID.
+	Channel(channelID snowflake.ID) (discord.GuildChannel, bool)
+
+	// Member returns the discord.Member of the given guild & user.
+	Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool)
+
+	// Message returns the discord.Message with the given ID.
+	Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool)
+
+	// Messages returns all discord.Message(s) of the given channel from oldest to newest.
+	Messages(channelID snowflake.ID) []discord.Message
+}
+
+var _ Server = (*serverImpl)(nil)
+
+// New starts a new Server with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Server {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	s := &serverImpl{
+		config:      *config,
+		store:       newStore(),
+		rateLimiter: newRateLimiter(config.RateLimit, config.RateLimitResetAfter),
+	}
+	s.store.users[config.BotUser.ID], _ = newObject(config.BotUser)
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+type serverImpl struct {
+	config      Config
+	server      *httptest.Server
+	store       *store
+	rateLimiter *rateLimiter
+}
+
+func (s *serverImpl) Logger() log.Logger {
+	return s.config.Logger
+}
+
+func (s *serverImpl) URL() string {
+	return s.server.URL + "/api/v" + route.APIVersion
+}
+
+func (s *serverImpl) HTTPClient() *http.Client {
+	return s.server.Client()
+}
+
+func (s *serverImpl) RestConfigOpts() []rest.ConfigOpt {
+	return []rest.ConfigOpt{
+		rest.WithURL(s.URL()),
+		rest.WithHTTPClient(s.HTTPClient()),
+	}
+}
+
+func (s *serverImpl) Close() {
+	s.server.Close()
+}
+
+// request is an incoming request matched to a route.APIRoute.
+type request struct {
+	*http.Request
+	route  *route.APIRoute
+	params map[string]string
+	body   []byte
+}
+
+// id returns the snowflake.ID of the given path parameter or 0.
+func (r *request) id(param string) snowflake.ID {
+	id, _ := snowflake.Parse(r.params[param])
+	return id
+}
+
+// object parses the JSON body or the payload_json of a multipart body.
+// The names of uploaded files are returned by the index of their files[n] field.
+func (r *request) object() (object, map[int]*multipart.FileHeader, *apiError) {
+	var (
+		payload = r.body
+		files   map[int]*multipart.FileHeader
+	)
+	mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		form, err := multipart.NewReader(bytes.NewReader(r.body), params["boundary"]).ReadForm(32 << 20)
+		if err != nil {
+			return nil, nil, errInvalidJSON
+		}
+		if values := form.Value["payload_json"]; len(values) > 0 {
+			payload = []byte(values[0])
+		}
+		files = map[int]*multipart.FileHeader{}
+		for field, headers := range form.File {
+			var i int
+			if _, err = fmt.Sscanf(field, "files[%d]", &i); err == nil && len(headers) > 0 {
+				files[i] = headers[0]
+			}
+		}
+	}
+	if len(payload) == 0 {
+		return object{}, files, nil
+	}
+	var o object
+	if err := json.Unmarshal(payload, &o); err != nil {
+		return nil, nil, errInvalidJSON
+	}
+	return o, files, nil
+}
+
+func (s *serverImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// without a Via header the rest.RateLimiter treats a 429 as cloudflare ban
+	w.Header().Set("Via", "1.1 google")
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v"+route.APIVersion)
+	if path == r.URL.Path {
+		s.writeResponse(w, errNotFound.status, errNotFound)
+		return
+	}
+
+	h, params, err := matchRoute(r.Method, path)
+	if err != nil {
+		s.Logger().Debugf("disgotest: no route for %s %s", r.Method, r.URL.Path)
+		s.writeResponse(w, err.status, err)
+		return
+	}
+
+	if h.route.NeedsBotAuth() && !s.authorized(r.Header.Get("Authorization")) {
+		s.writeResponse(w, errUnauthorized.status, errUnauthorized)
+		return
+	}
+
+	if ok, retryAfter := s.rateLimiter.take(w.Header(), h.route, majorParams(params)); !ok {
+		s.writeResponse(w, http.StatusTooManyRequests, map[string]any{
+			"message":     "You are being rate limited.",
+			"retry_after": float64(retryAfter.Milliseconds()) / 1000,
+			"global":      false,
+		})
+		return
+	}
+
+	body, rErr := io.ReadAll(r.Body)
+	if rErr != nil {
+		s.writeResponse(w, errInvalidJSON.status, errInvalidJSON)
+		return
+	}
+
+	// the response is written while locked as handlers may respond with objects of the store
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	status, rsBody := h.handle(s, &request{
+		Request: r,
+		route:   h.route,
+		params:  params,
+		body:    body,
+	})
+	s.writeResponse(w, status, rsBody)
+}
+
+// authorized returns whether the Authorization header contains the configured or any bot token.
+func (s *serverImpl) authorized(authorization string) bool {
+	if s.config.Token != "" {
+		return authorization == discord.TokenTypeBot.Apply(s.config.Token)
+	}
+	return strings.HasPrefix(authorization, discord.TokenTypeBot.String()+" ") && len(authorization) > len(discord.TokenTypeBot.String())+1
+}
+
+func (s *serverImpl) writeResponse(w http.ResponseWriter, status int, body any) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to marshal response: %s", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+// majorParams returns the major parameters of the matched path parameters like route.CompiledAPIRoute.MajorParams.
+func majorParams(params map[string]string) string {
+	var major []string
+	for _, param := range strings.Split(route.MajorParameters, ":") {
+		if value, ok := params[param]; ok {
+			major = append(major, param+"="+value)
+		}
+	}
+	return strings.Join(major, ":")
+}
+
+func (s *serverImpl) BotUser() discord.User {
+	return s.config.BotUser
+}
+
+func (s *serverImpl) AddUser(user discord.User) {
+	o, err := newObject(user)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to add user: %s", err)
+		return
+	}
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	s.store.users[user.ID] = o
+}
+
+func (s *serverImpl) AddGuild(guild discord.Guild) discord.Guild {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	if guild.ID == 0 {
+		guild.ID = s.store.newID()
+	}
+	o, err := newObject(guild)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to add guild: %s", err)
+		return guild
+	}
+	s.store.guilds[guild.ID] = o
+	return guild
+}
+
+func (s *serverImpl) AddChannel(channel discord.GuildChannel) {
+	o, err := newObject(channel)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to add channel: %s", err)
+		return
+	}
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	s.store.channels[channel.ID()] = o
+}
+
+func (s *serverImpl) CreateGuildChannel(guildID snowflake.ID, channelCreate discord.GuildChannelCreate) discord.GuildChannel {
+	o, err := newObject(channelCreate)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to create channel: %s", err)
+		return nil
+	}
+	s.store.mu.Lock()
+	channel := s.createGuildChannel(guildID, o)
+	s.store.mu.Unlock()
+	return decodeChannel(channel)
+}
+
+func (s *serverImpl) AddMember(member discord.Member) {
+	o, err := newObject(member)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to add member: %s", err)
+		return
+	}
+	delete(o, "guild_id")
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	s.store.putMember(member.GuildID, o)
+}
+
+func (s *serverImpl) AddMessage(message discord.Message) discord.Message {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	if message.ID == 0 {
+		message.ID = s.store.newID()
+	}
+	if message.CreatedAt.IsZero() {
+		message.CreatedAt = time.Now()
+	}
+	o, err := newObject(message)
+	if err != nil {
+		s.Logger().Errorf("disgotest: failed to add message: %s", err)
+		return message
+	}
+	s.store.putMessage(o)
+	return message
+}
+
+func (s *serverImpl) Guild(guildID snowflake.ID) (discord.Guild, bool) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	var guild discord.Guild
+	o, ok := s.store.guilds[guildID]
+	if !ok || o.decode(&guild) != nil {
+		return guild, false
+	}
+	return guild, true
+}
+
+func (s *serverImpl) Channel(channelID snowflake.ID) (discord.GuildChannel, bool) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	o, ok := s.store.channels[channelID]
+	if !ok {
+		return nil, false
+	}
+	channel := decodeChannel(o)
+	return channel, channel != nil
+}
+
+func (s *serverImpl) Member(guildID snowflake.ID, userID snowflake.ID) (discord.Member, bool) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	var member discord.Member
+	o, ok := s.store.members[guildID][userID]
+	if !ok || o.decode(&member) != nil {
+		return member, false
+	}
+	member.GuildID = guildID
+	return member, true
+}
+
+func (s *serverImpl) Message(channelID snowflake.ID, messageID snowflake.ID) (discord.Message, bool) {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	var message discord.Message
+	_, o, ok := s.store.message(channelID, messageID)
+	if !ok || o.decode(&message) != nil {
+		return message, false
+	}
+	return message, true
+}
+
+func (s *serverImpl) Messages(channelID snowflake.ID) []discord.Message {
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+	messages := make([]discord.Message, 0, len(s.store.messages[channelID]))
+	for _, o := range s.store.messages[channelID] {
+		var message discord.Message
+		if err := o.decode(&message); err != nil {
+			continue
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// decodeChannel returns the discord.GuildChannel of the given object or nil.
+func decodeChannel(o object) discord.GuildChannel {
+	var channel discord.UnmarshalChannel
+	if err := o.decode(&channel); err != nil {
+		return nil
+	}
+	guildChannel, _ := channel.Channel.(discord.GuildChannel)
+	return guildChannel
+}
@@ -0,0 +1,78 @@
+package disgotest
+
+import (
+	"time"
+
+	"github.com/disgoorg/log"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger: log.Default(),
+		BotUser: discord.User{
+			ID:            123,
+			Username:      "disgotest",
+			Discriminator: "0000",
+			Bot:           true,
+		},
+		RateLimit:           50,
+		RateLimitResetAfter: time.Second,
+	}
+}
+
+// Config lets you configure your Server instance.
+type Config struct {
+	Logger log.Logger
+
+	// Token is the bot token requests need to be authorized with. If it is empty, any bot token is accepted.
+	Token string
+
+	// BotUser is the discord.User returned for the current user & used as author of created messages.
+	BotUser discord.User
+
+	// RateLimit is the number of requests per bucket which are allowed until RateLimitResetAfter passed.
+	RateLimit           int
+	RateLimitResetAfter time.Duration
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Server.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithToken sets the bot token requests need to be authorized with.
+func WithToken(token string) ConfigOpt {
+	return func(config *Config) {
+		config.Token = token
+	}
+}
+
+// WithBotUser sets the discord.User of the bot.
+func WithBotUser(user discord.User) ConfigOpt {
+	return func(config *Config) {
+		config.BotUser = user
+	}
+}
+
+// WithRateLimit sets how many requests per bucket are allowed in the given time frame.
+func WithRateLimit(limit int, resetAfter time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.RateLimit = limit
+		config.RateLimitResetAfter = resetAfter
+	}
+}
@@ -0,0 +1,166 @@
+package disgotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+)
+
+func newRest(t *testing.T, server Server) rest.Rest {
+	client := rest.NewClient("MTIz.test.token", server.RestConfigOpts()...)
+	t.Cleanup(func() {
+		client.Close(context.Background())
+	})
+	return rest.New(client)
+}
+
+func TestServer_Messages(t *testing.T) {
+	server := New()
+	defer server.Close()
+	client := newRest(t, server)
+
+	guild := server.AddGuild(discord.Guild{Name: "Test Guild"})
+	channel, err := client.CreateGuildChannel(guild.ID, discord.GuildTextChannelCreate{Name: "general"})
+	require.NoError(t, err)
+	assert.Equal(t, "general", channel.Name())
+	assert.Equal(t, guild.ID, channel.GuildID())
+
+	for _, content := range []string{"one", "two", "three"} {
+		message, err := client.CreateMessage(channel.ID(), discord.MessageCreate{Content: content})
+		require.NoError(t, err)
+		assert.Equal(t, server.BotUser().ID, message.Author.ID)
+	}
+
+	messages, err := client.GetMessages(channel.ID(), 0, 0, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, "three", messages[0].Content)
+	assert.Equal(t, "two", messages[1].Content)
+
+	message, err := client.UpdateMessage(channel.ID(), messages[1].ID, discord.MessageUpdate{Content: json.NewPtr("edited")})
+	require.NoError(t, err)
+	assert.Equal(t, "edited", message.Content)
+	assert.NotNil(t, message.EditedTimestamp)
+
+	require.NoError(t, client.AddReaction(channel.ID(), message.ID, "👍"))
+	stored, ok := server.Message(channel.ID(), message.ID)
+	require.True(t, ok)
+	require.Len(t, stored.Reactions, 1)
+	assert.Equal(t, "👍", stored.Reactions[0].Emoji.Name)
+	assert.True(t, stored.Reactions[0].Me)
+
+	message, err = client.CreateMessage(channel.ID(), discord.MessageCreate{
+		Files: []*discord.File{discord.NewFile("test.txt", "a test file", strings.NewReader("test"))},
+	})
+	require.NoError(t, err)
+	require.Len(t, message.Attachments, 1)
+	assert.Equal(t, "test.txt", message.Attachments[0].Filename)
+	assert.Equal(t, 4, message.Attachments[0].Size)
+
+	require.NoError(t, client.DeleteMessage(channel.ID(), messages[0].ID))
+	assert.Len(t, server.Messages(channel.ID()), 3)
+
+	_, err = client.GetMessage(channel.ID(), messages[0].ID)
+	assertAPIError(t, err, http.StatusNotFound, codeUnknownMessage)
+
+	_, err = client.CreateMessage(channel.ID(), discord.MessageCreate{})
+	assertAPIError(t, err, http.StatusBadRequest, codeEmptyMessage)
+}
+
+func TestServer_Members(t *testing.T) {
+	server := New()
+	defer server.Close()
+	client := newRest(t, server)
+
+	guild := server.AddGuild(discord.Guild{Name: "Test Guild"})
+	for i, name := range []string{"alice", "bob"} {
+		server.AddMember(discord.Member{
+			User:    discord.User{ID: snowflake.ID(1000 + i), Username: name},
+			GuildID: guild.ID,
+		})
+	}
+
+	members, err := client.GetMembers(guild.ID)
+	require.NoError(t, err)
+	// like Discord, only one member is returned by default
+	require.Len(t, members, 1)
+	assert.Equal(t, "alice", members[0].User.Username)
+
+	member, err := client.UpdateMember(guild.ID, 1001, discord.MemberUpdate{Nick: json.NewPtr("bobby")})
+	require.NoError(t, err)
+	assert.Equal(t, "bobby", *member.Nick)
+
+	require.NoError(t, client.AddMemberRole(guild.ID, 1001, 42))
+	stored, ok := server.Member(guild.ID, 1001)
+	require.True(t, ok)
+	assert.Equal(t, "bobby", *stored.Nick)
+	assert.Len(t, stored.RoleIDs, 1)
+
+	_, err = client.GetMember(guild.ID, 1002)
+	assertAPIError(t, err, http.StatusNotFound, codeUnknownMember)
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := New(WithRateLimit(1, 500*time.Millisecond))
+	defer server.Close()
+	client := newRest(t, server)
+
+	guild := server.AddGuild(discord.Guild{Name: "Test Guild"})
+	start := time.Now()
+	_, err := client.GetGuild(guild.ID, false)
+	require.NoError(t, err)
+	// the rest.RateLimiter has to wait for the reset of the bucket
+	_, err = client.GetGuild(guild.ID, false)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	rq, err := http.NewRequest(http.MethodGet, server.URL()+"/guilds/"+guild.ID.String(), nil)
+	require.NoError(t, err)
+	rq.Header.Set("Authorization", "Bot MTIz.test.token")
+	rs, err := server.HTTPClient().Do(rq)
+	require.NoError(t, err)
+	defer rs.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, rs.StatusCode)
+	assert.Equal(t, "0", rs.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "1", rs.Header.Get("Retry-After"))
+	assert.NotEmpty(t, rs.Header.Get("X-RateLimit-Bucket"))
+
+	var rateLimited struct {
+		Message    string  `json:"message"`
+		RetryAfter float64 `json:"retry_after"`
+		Global     bool    `json:"global"`
+	}
+	require.NoError(t, json.NewDecoder(rs.Body).Decode(&rateLimited))
+	assert.Equal(t, "You are being rate limited.", rateLimited.Message)
+	assert.False(t, rateLimited.Global)
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	server := New(WithToken("MTIz.test.token"))
+	defer server.Close()
+
+	_, err := rest.New(rest.NewClient("MTIz.wrong.token", server.RestConfigOpts()...)).GetCurrentUser("")
+	assertAPIError(t, err, http.StatusUnauthorized, codeGeneral)
+}
+
+func assertAPIError(t *testing.T, err error, status int, code int) {
+	var restErr *rest.Error
+	require.True(t, errors.As(err, &restErr), "expected a *rest.Error, got %v", err)
+	assert.Equal(t, status, restErr.Response.StatusCode)
+
+	var body apiError
+	require.NoError(t, json.Unmarshal(restErr.RsBody, &body))
+	assert.Equal(t, code, body.Code)
+	assert.NotEmpty(t, body.Message)
+}
@@ -0,0 +1,209 @@
+package disgotest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+// object is a JSON object of the in-memory model. Keeping the raw fields lets PATCH requests be merged like Discord does.
+type object map[string]json.RawMessage
+
+// newObject marshals the given value into an object.
+func newObject(v any) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var o object
+	if err = json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// set sets the given key to the marshalled value.
+func (o object) set(key string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	o[key] = data
+}
+
+// has returns whether the given key is set and not null.
+func (o object) has(key string) bool {
+	v, ok := o[key]
+	return ok && string(v) != "null"
+}
+
+// get unmarshalls the value of the given key into v.
+func (o object) get(key string, v any) bool {
+	if !o.has(key) {
+		return false
+	}
+	return json.Unmarshal(o[key], v) == nil
+}
+
+// id returns the snowflake.ID of the given key or 0.
+func (o object) id(key string) snowflake.ID {
+	var id snowflake.ID
+	o.get(key, &id)
+	return id
+}
+
+// merge sets all fields of the update.
+func (o object) merge(update object) {
+	for key, value := range update {
+		o[key] = value
+	}
+}
+
+// copy returns a shallow copy of the object, which is safe to hand out as the values are never modified in place.
+func (o object) copy() object {
+	c := make(object, len(o))
+	for key, value := range o {
+		c[key] = value
+	}
+	return c
+}
+
+// decode unmarshalls the object into v.
+func (o object) decode(v any) error {
+	data, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// store is the in-memory model of guilds, channels, messages, members & users.
+type store struct {
+	mu sync.Mutex
+
+	lastID   snowflake.ID
+	users    map[snowflake.ID]object
+	guilds   map[snowflake.ID]object
+	channels map[snowflake.ID]object
+	// messages are sorted by their id
+	messages map[snowflake.ID][]object
+	members  map[snowflake.ID]map[snowflake.ID]object
+}
+
+func newStore() *store {
+	return &store{
+		users:    map[snowflake.ID]object{},
+		guilds:   map[snowflake.ID]object{},
+		channels: map[snowflake.ID]object{},
+		messages: map[snowflake.ID][]object{},
+		members:  map[snowflake.ID]map[snowflake.ID]object{},
+	}
+}
+
+// newID returns a unique snowflake.ID of the current time. The store needs to be locked.
+func (s *store) newID() snowflake.ID {
+	id := snowflake.New(time.Now())
+	if id <= s.lastID {
+		id = s.lastID + 1
+	}
+	s.lastID = id
+	return id
+}
+
+// putMessage adds or replaces the message in its channel. The store needs to be locked.
+func (s *store) putMessage(message object) {
+	channelID := message.id("channel_id")
+	messageID := message.id("id")
+	messages := s.messages[channelID]
+	i := sort.Search(len(messages), func(i int) bool {
+		return messages[i].id("id") >= messageID
+	})
+	if i < len(messages) && messages[i].id("id") == messageID {
+		messages[i] = message
+		return
+	}
+	messages = append(messages, nil)
+	copy(messages[i+1:], messages[i:])
+	messages[i] = message
+	s.messages[channelID] = messages
+}
+
+// message returns the index & message in the given channel. The store needs to be locked.
+func (s *store) message(channelID snowflake.ID, messageID snowflake.ID) (int, object, bool) {
+	messages := s.messages[channelID]
+	i := sort.Search(len(messages), func(i int) bool {
+		return messages[i].id("id") >= messageID
+	})
+	if i < len(messages) && messages[i].id("id") == messageID {
+		return i, messages[i], true
+	}
+	return -1, nil, false
+}
+
+// deleteMessage removes the message from its channel. The store needs to be locked.
+func (s *store) deleteMessage(channelID snowflake.ID, messageID snowflake.ID) bool {
+	i, _, ok := s.message(channelID, messageID)
+	if !ok {
+		return false
+	}
+	s.messages[channelID] = append(s.messages[channelID][:i], s.messages[channelID][i+1:]...)
+	return true
+}
+
+// putMember adds or replaces the member of the given guild. The store needs to be locked.
+func (s *store) putMember(guildID snowflake.ID, member object) {
+	var user object
+	if !member.get("user", &user) {
+		return
+	}
+	userID := user.id("id")
+	if _, ok := s.users[userID]; !ok {
+		s.users[userID] = user
+	}
+	if _, ok := s.members[guildID]; !ok {
+		s.members[guildID] = map[snowflake.ID]object{}
+	}
+	s.members[guildID][userID] = member
+}
+
+// sortedMembers returns the members of the given guild sorted by their user id. The store needs to be locked.
+func (s *store) sortedMembers(guildID snowflake.ID) []object {
+	members := make([]object, 0, len(s.members[guildID]))
+	for _, member := range s.members[guildID] {
+		members = append(members, member.copy())
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return memberUserID(members[i]) < memberUserID(members[j])
+	})
+	return members
+}
+
+// guildChannels returns the channels of the given guild sorted by their position & id. The store needs to be locked.
+func (s *store) guildChannels(guildID snowflake.ID) []object {
+	var channels []object
+	for _, channel := range s.channels {
+		if channel.id("guild_id") == guildID {
+			channels = append(channels, channel.copy())
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		var pi, pj int
+		channels[i].get("position", &pi)
+		channels[j].get("position", &pj)
+		if pi != pj {
+			return pi < pj
+		}
+		return channels[i].id("id") < channels[j].id("id")
+	})
+	return channels
+}
+
+func memberUserID(member object) snowflake.ID {
+	var user object
+	member.get("user", &user)
+	return user.id("id")
+}
@@ -12,3 +12,15 @@ type Ready struct {
 type Resumed struct {
 	*GenericEvent
 }
+
+// GatewayStatusChange indicates the gateway.Status of a gateway.Gateway changed
+type GatewayStatusChange struct {
+	*GenericEvent
+	gateway.EventStatusChange
+}
+
+// Zombied indicates the gateway.Gateway did not receive a heartbeat ACK and reconnects to resume the session
+type Zombied struct {
+	*GenericEvent
+	gateway.EventZombied
+}
@@ -68,8 +68,10 @@ type ListenerAdapter struct {
 	OnStickerDelete  func(event *StickerDelete)
 
 	// gateway status Events
-	OnReady   func(event *Ready)
-	OnResumed func(event *Resumed)
+	OnReady               func(event *Ready)
+	OnResumed             func(event *Resumed)
+	OnZombied             func(event *Zombied)
+	OnGatewayStatusChange func(event *GatewayStatusChange)
 
 	// Guild Events
 	OnGuildJoin        func(event *GuildJoin)
@@ -349,6 +351,14 @@ func (l *ListenerAdapter) OnEvent(event bot.Event) {
 		if listener := l.OnResumed; listener != nil {
 			listener(e)
 		}
+	case *Zombied:
+		if listener := l.OnZombied; listener != nil {
+			listener(e)
+		}
+	case *GatewayStatusChange:
+		if listener := l.OnGatewayStatusChange; listener != nil {
+			listener(e)
+		}
 
 	// Guild Events
 	case *GuildJoin:
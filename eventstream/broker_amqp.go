@@ -0,0 +1,533 @@
+package eventstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+// DefaultAMQPConfig returns a AMQPConfig with sensible defaults.
+func DefaultAMQPConfig() *AMQPConfig {
+	return &AMQPConfig{
+		Logger:       log.Default(),
+		Dialer:       &net.Dialer{},
+		Exchange:     "discord",
+		ExchangeType: "topic",
+	}
+}
+
+// AMQPConfig lets you configure your AMQP Broker instance.
+type AMQPConfig struct {
+	Logger log.Logger
+	Dialer *net.Dialer
+
+	// Exchange is the exchange the Message(s) are published to with their topic as routing key.
+	Exchange string
+	// ExchangeType is used to declare the Exchange as durable exchange. The Exchange is not declared if it is empty.
+	ExchangeType string
+}
+
+// AMQPConfigOpt is a type alias for a function that takes a AMQPConfig and is used to configure your AMQP Broker.
+type AMQPConfigOpt func(config *AMQPConfig)
+
+// Apply applies the given AMQPConfigOpt(s) to the AMQPConfig
+func (c *AMQPConfig) Apply(opts []AMQPConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithAMQPLogger sets the logger of the AMQP Broker.
+func WithAMQPLogger(logger log.Logger) AMQPConfigOpt {
+	return func(config *AMQPConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithAMQPDialer sets the net.Dialer used to connect to the AMQP server.
+func WithAMQPDialer(dialer *net.Dialer) AMQPConfigOpt {
+	return func(config *AMQPConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithAMQPExchange sets the exchange the Message(s) are published to and the type it is declared with.
+// Pass an empty exchangeType if the exchange already exists or to publish to the default exchange.
+func WithAMQPExchange(exchange string, exchangeType string) AMQPConfigOpt {
+	return func(config *AMQPConfig) {
+		config.Exchange = exchange
+		config.ExchangeType = exchangeType
+	}
+}
+
+const (
+	amqpProtocolHeader = "AMQP\x00\x00\x09\x01"
+
+	amqpFrameMethod    = 1
+	amqpFrameHeader    = 2
+	amqpFrameBody      = 3
+	amqpFrameHeartbeat = 8
+	amqpFrameEnd       = 0xCE
+
+	amqpChannel     = 1
+	amqpMaxFrameMax = 128 * 1024
+
+	amqpBasicClass      uint16 = 60
+	amqpContentTypeFlag uint16 = 1 << 15
+	amqpContentType            = "application/json"
+)
+
+// amqpMethod identifies an AMQP method by its class & method id.
+type amqpMethod struct {
+	class  uint16
+	method uint16
+}
+
+var (
+	amqpConnectionStart   = amqpMethod{10, 10}
+	amqpConnectionStartOk = amqpMethod{10, 11}
+	amqpConnectionTune    = amqpMethod{10, 30}
+	amqpConnectionTuneOk  = amqpMethod{10, 31}
+	amqpConnectionOpen    = amqpMethod{10, 40}
+	amqpConnectionOpenOk  = amqpMethod{10, 41}
+	amqpConnectionClose   = amqpMethod{10, 50}
+	amqpConnectionCloseOk = amqpMethod{10, 51}
+	amqpChannelOpen       = amqpMethod{20, 10}
+	amqpChannelOpenOk     = amqpMethod{20, 11}
+	amqpChannelClose      = amqpMethod{20, 40}
+	amqpChannelCloseOk    = amqpMethod{20, 41}
+	amqpExchangeDeclare   = amqpMethod{40, 10}
+	amqpExchangeDeclareOk = amqpMethod{40, 11}
+	amqpBasicPublish      = amqpMethod{60, 40}
+)
+
+var errAMQPUnexpectedFrame = errors.New("amqp: unexpected frame")
+
+var _ Broker = (*amqpBroker)(nil)
+
+// NewAMQPBroker connects to the AMQP 0-9-1 server at the given URL and returns a Broker which publishes each Message to the configured exchange with its topic as routing key.
+// The URL has the format amqp://[user:password@]host[:port][/vhost] and defaults to the guest user and the / vhost. The connection is re-established on the next publish if it is lost, but not after the Broker is closed.
+// This speaks the plain AMQP 0-9-1 protocol and does not support TLS, heartbeats or publisher confirms.
+func NewAMQPBroker(ctx context.Context, amqpURL string, opts ...AMQPConfigOpt) (Broker, error) {
+	config := DefaultAMQPConfig()
+	config.Apply(opts)
+
+	u, err := url.Parse(amqpURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "amqp" {
+		return nil, fmt.Errorf("unsupported amqp url scheme: %s", u.Scheme)
+	}
+
+	b := &amqpBroker{
+		url:    u,
+		config: *config,
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err = b.connect(ctx); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+type amqpBroker struct {
+	url    *url.URL
+	config AMQPConfig
+
+	conn     net.Conn
+	writer   *bufio.Writer
+	frameMax int
+	closed   bool
+	mu       sync.Mutex
+}
+
+// connect connects to the AMQP server, opens the channel & declares the exchange. It must be called while holding mu.
+func (b *amqpBroker) connect(ctx context.Context) error {
+	host := b.url.Host
+	if b.url.Port() == "" {
+		host = net.JoinHostPort(b.url.Hostname(), "5672")
+	}
+	conn, err := b.config.Dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	frameMax, err := b.handshake(reader, writer)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	b.conn = conn
+	b.writer = writer
+	b.frameMax = frameMax
+	go b.read(conn, reader)
+	return nil
+}
+
+// handshake opens the connection & channel and declares the exchange. It returns the negotiated max frame size.
+func (b *amqpBroker) handshake(reader *bufio.Reader, writer *bufio.Writer) (int, error) {
+	if _, err := writer.WriteString(amqpProtocolHeader); err != nil {
+		return 0, err
+	}
+	if err := writer.Flush(); err != nil {
+		return 0, err
+	}
+	if _, err := expectAMQPMethod(reader, amqpConnectionStart); err != nil {
+		return 0, err
+	}
+
+	user, pass := "guest", "guest"
+	if u := b.url.User; u != nil {
+		user = u.Username()
+		pass, _ = u.Password()
+	}
+	startOk := &amqpArgs{}
+	startOk.table(map[string]string{"product": "disgo"})
+	startOk.shortstr("PLAIN")
+	startOk.longstr("\x00" + user + "\x00" + pass)
+	startOk.shortstr("en_US")
+	if err := writeAMQPMethod(writer, 0, amqpConnectionStartOk, startOk); err != nil {
+		return 0, err
+	}
+
+	tune, err := expectAMQPMethod(reader, amqpConnectionTune)
+	if err != nil {
+		return 0, err
+	}
+	if len(tune) < 8 {
+		return 0, errAMQPUnexpectedFrame
+	}
+	channelMax := binary.BigEndian.Uint16(tune)
+	frameMax := int(binary.BigEndian.Uint32(tune[2:]))
+	if frameMax == 0 || frameMax > amqpMaxFrameMax {
+		frameMax = amqpMaxFrameMax
+	}
+	tuneOk := &amqpArgs{}
+	tuneOk.uint16(channelMax)
+	tuneOk.uint32(uint32(frameMax))
+	// heartbeats are disabled
+	tuneOk.uint16(0)
+	if err = writeAMQPMethod(writer, 0, amqpConnectionTuneOk, tuneOk); err != nil {
+		return 0, err
+	}
+
+	vhost := strings.TrimPrefix(b.url.Path, "/")
+	if vhost == "" {
+		vhost = "/"
+	}
+	open := &amqpArgs{}
+	open.shortstr(vhost)
+	open.shortstr("")
+	open.octet(0)
+	if err = writeAMQPMethod(writer, 0, amqpConnectionOpen, open); err != nil {
+		return 0, err
+	}
+	if _, err = expectAMQPMethod(reader, amqpConnectionOpenOk); err != nil {
+		return 0, err
+	}
+
+	channelOpen := &amqpArgs{}
+	channelOpen.shortstr("")
+	if err = writeAMQPMethod(writer, amqpChannel, amqpChannelOpen, channelOpen); err != nil {
+		return 0, err
+	}
+	if _, err = expectAMQPMethod(reader, amqpChannelOpenOk); err != nil {
+		return 0, err
+	}
+
+	if b.config.ExchangeType != "" {
+		declare := &amqpArgs{}
+		declare.uint16(0)
+		declare.shortstr(b.config.Exchange)
+		declare.shortstr(b.config.ExchangeType)
+		// durable
+		declare.octet(1 << 1)
+		declare.table(nil)
+		if err = writeAMQPMethod(writer, amqpChannel, amqpExchangeDeclare, declare); err != nil {
+			return 0, err
+		}
+		if _, err = expectAMQPMethod(reader, amqpExchangeDeclareOk); err != nil {
+			return 0, err
+		}
+	}
+	return frameMax, nil
+}
+
+// read answers the close requests of the server until the connection is closed.
+func (b *amqpBroker) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		frameType, channel, payload, err := readAMQPFrame(reader)
+		if err == nil && frameType != amqpFrameMethod {
+			continue
+		}
+		var method amqpMethod
+		if err == nil {
+			method, payload, err = parseAMQPMethod(payload)
+		}
+		if err == nil {
+			switch method {
+			case amqpConnectionClose:
+				err = amqpCloseError(payload)
+				b.reply(conn, 0, amqpConnectionCloseOk)
+			case amqpChannelClose:
+				// the channel can't be used anymore, so we close the connection and reconnect on the next publish
+				err = amqpCloseError(payload)
+				b.reply(conn, channel, amqpChannelCloseOk)
+			default:
+				continue
+			}
+		}
+
+		b.mu.Lock()
+		if b.conn == conn {
+			b.config.Logger.Errorf("amqp connection lost: %s", err)
+			_ = conn.Close()
+			b.conn = nil
+		}
+		b.mu.Unlock()
+		return
+	}
+}
+
+// reply sends the given method without arguments if the connection is still in use.
+func (b *amqpBroker) reply(conn net.Conn, channel uint16, method amqpMethod) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn == conn {
+		_ = writeAMQPMethod(b.writer, channel, method, &amqpArgs{})
+	}
+}
+
+func (b *amqpBroker) Publish(ctx context.Context, message Message) error {
+	if len(message.Topic) > 255 {
+		return fmt.Errorf("amqp routing key is longer than 255 bytes: %q", message.Topic)
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrBrokerClosed
+	}
+	if b.conn == nil {
+		if err = b.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = b.conn.SetWriteDeadline(deadline)
+	if err = b.writePublish(message.Topic, data); err != nil {
+		_ = b.conn.Close()
+		b.conn = nil
+	}
+	return err
+}
+
+// writePublish writes the publish method followed by the content header & body frames. It must be called while holding mu.
+func (b *amqpBroker) writePublish(routingKey string, data []byte) error {
+	publish := &amqpArgs{}
+	publish.uint16(0)
+	publish.shortstr(b.config.Exchange)
+	publish.shortstr(routingKey)
+	publish.octet(0)
+	payload := publish.method(amqpBasicPublish)
+	if err := writeAMQPFrame(b.writer, amqpFrameMethod, amqpChannel, payload); err != nil {
+		return err
+	}
+
+	header := &amqpArgs{}
+	header.uint16(amqpBasicClass)
+	header.uint16(0)
+	header.uint64(uint64(len(data)))
+	header.uint16(amqpContentTypeFlag)
+	header.shortstr(amqpContentType)
+	if err := writeAMQPFrame(b.writer, amqpFrameHeader, amqpChannel, header.Bytes()); err != nil {
+		return err
+	}
+
+	// the frame header & end take 8 bytes of the max frame size
+	chunkSize := b.frameMax - 8
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > chunkSize {
+			chunk = chunk[:chunkSize]
+		}
+		if err := writeAMQPFrame(b.writer, amqpFrameBody, amqpChannel, chunk); err != nil {
+			return err
+		}
+		data = data[len(chunk):]
+	}
+	return b.writer.Flush()
+}
+
+func (b *amqpBroker) Close(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.conn == nil {
+		return nil
+	}
+	closeArgs := &amqpArgs{}
+	closeArgs.uint16(200)
+	closeArgs.shortstr("")
+	closeArgs.uint16(0)
+	closeArgs.uint16(0)
+	_ = writeAMQPMethod(b.writer, 0, amqpConnectionClose, closeArgs)
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
+
+// amqpArgs encodes the arguments of an AMQP method or content header.
+type amqpArgs struct {
+	bytes.Buffer
+}
+
+func (a *amqpArgs) octet(v byte) {
+	a.WriteByte(v)
+}
+
+func (a *amqpArgs) uint16(v uint16) {
+	_ = binary.Write(a, binary.BigEndian, v)
+}
+
+func (a *amqpArgs) uint32(v uint32) {
+	_ = binary.Write(a, binary.BigEndian, v)
+}
+
+func (a *amqpArgs) uint64(v uint64) {
+	_ = binary.Write(a, binary.BigEndian, v)
+}
+
+func (a *amqpArgs) shortstr(v string) {
+	a.octet(byte(len(v)))
+	a.WriteString(v)
+}
+
+func (a *amqpArgs) longstr(v string) {
+	a.uint32(uint32(len(v)))
+	a.WriteString(v)
+}
+
+// table encodes a field table with string values.
+func (a *amqpArgs) table(fields map[string]string) {
+	table := &amqpArgs{}
+	for key, value := range fields {
+		table.shortstr(key)
+		table.octet('S')
+		table.longstr(value)
+	}
+	a.uint32(uint32(table.Len()))
+	a.Write(table.Bytes())
+}
+
+// method returns the payload of a method frame with the arguments.
+func (a *amqpArgs) method(method amqpMethod) []byte {
+	payload := &amqpArgs{}
+	payload.uint16(method.class)
+	payload.uint16(method.method)
+	payload.Write(a.Bytes())
+	return payload.Bytes()
+}
+
+func writeAMQPFrame(writer io.Writer, frameType byte, channel uint16, payload []byte) error {
+	frame := make([]byte, 7, 8+len(payload))
+	frame[0] = frameType
+	binary.BigEndian.PutUint16(frame[1:], channel)
+	binary.BigEndian.PutUint32(frame[3:], uint32(len(payload)))
+	frame = append(frame, payload...)
+	frame = append(frame, amqpFrameEnd)
+	_, err := writer.Write(frame)
+	return err
+}
+
+func writeAMQPMethod(writer *bufio.Writer, channel uint16, method amqpMethod, args *amqpArgs) error {
+	if err := writeAMQPFrame(writer, amqpFrameMethod, channel, args.method(method)); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func readAMQPFrame(reader io.Reader) (byte, uint16, []byte, error) {
+	var header [7]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	if payload[len(payload)-1] != amqpFrameEnd {
+		return 0, 0, nil, errAMQPUnexpectedFrame
+	}
+	return header[0], binary.BigEndian.Uint16(header[1:]), payload[:len(payload)-1], nil
+}
+
+func parseAMQPMethod(payload []byte) (amqpMethod, []byte, error) {
+	if len(payload) < 4 {
+		return amqpMethod{}, nil, errAMQPUnexpectedFrame
+	}
+	return amqpMethod{binary.BigEndian.Uint16(payload), binary.BigEndian.Uint16(payload[2:])}, payload[4:], nil
+}
+
+// expectAMQPMethod reads frames until the given method is received and returns its arguments.
+// Heartbeats are skipped and close methods are returned as error.
+func expectAMQPMethod(reader io.Reader, expected amqpMethod) ([]byte, error) {
+	for {
+		frameType, _, payload, err := readAMQPFrame(reader)
+		if err != nil {
+			return nil, err
+		}
+		if frameType == amqpFrameHeartbeat {
+			continue
+		}
+		if frameType != amqpFrameMethod {
+			return nil, errAMQPUnexpectedFrame
+		}
+		method, args, err := parseAMQPMethod(payload)
+		if err != nil {
+			return nil, err
+		}
+		switch method {
+		case expected:
+			return args, nil
+		case amqpConnectionClose, amqpChannelClose:
+			return nil, amqpCloseError(args)
+		default:
+			return nil, fmt.Errorf("amqp: unexpected method %d.%d", method.class, method.method)
+		}
+	}
+}
+
+// amqpCloseError returns the reason of a close method as error.
+func amqpCloseError(args []byte) error {
+	if len(args) < 3 || len(args) < 3+int(args[2]) {
+		return errors.New("amqp: closed by server")
+	}
+	return fmt.Errorf("amqp: closed by server: %d %s", binary.BigEndian.Uint16(args), args[3:3+int(args[2])])
+}
@@ -0,0 +1,120 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveAMQP is a stand-in AMQP server which accepts one connection and sends the exchange, routing key & body of the first publish.
+func serveAMQP(listener net.Listener, published chan<- []string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	header := make([]byte, len(amqpProtocolHeader))
+	if _, err = io.ReadFull(reader, header); err != nil || string(header) != amqpProtocolHeader {
+		return
+	}
+
+	start := &amqpArgs{}
+	start.octet(0)
+	start.octet(9)
+	start.table(nil)
+	start.longstr("PLAIN")
+	start.longstr("en_US")
+	_ = writeAMQPMethod(writer, 0, amqpConnectionStart, start)
+	if _, err = expectAMQPMethod(reader, amqpConnectionStartOk); err != nil {
+		return
+	}
+
+	tune := &amqpArgs{}
+	tune.uint16(2047)
+	tune.uint32(4096)
+	tune.uint16(60)
+	_ = writeAMQPMethod(writer, 0, amqpConnectionTune, tune)
+	if _, err = expectAMQPMethod(reader, amqpConnectionTuneOk); err != nil {
+		return
+	}
+	if _, err = expectAMQPMethod(reader, amqpConnectionOpen); err != nil {
+		return
+	}
+	_ = writeAMQPMethod(writer, 0, amqpConnectionOpenOk, &amqpArgs{})
+
+	if _, err = expectAMQPMethod(reader, amqpChannelOpen); err != nil {
+		return
+	}
+	_ = writeAMQPMethod(writer, amqpChannel, amqpChannelOpenOk, &amqpArgs{})
+	if _, err = expectAMQPMethod(reader, amqpExchangeDeclare); err != nil {
+		return
+	}
+	_ = writeAMQPMethod(writer, amqpChannel, amqpExchangeDeclareOk, &amqpArgs{})
+
+	args, err := expectAMQPMethod(reader, amqpBasicPublish)
+	if err != nil {
+		return
+	}
+	exchange := string(args[3 : 3+args[2]])
+	args = args[3+args[2]:]
+	routingKey := string(args[1 : 1+args[0]])
+
+	_, _, contentHeader, err := readAMQPFrame(reader)
+	if err != nil {
+		return
+	}
+	size := int(binary.BigEndian.Uint64(contentHeader[4:]))
+	var body []byte
+	for len(body) < size {
+		_, _, chunk, err := readAMQPFrame(reader)
+		if err != nil {
+			return
+		}
+		body = append(body, chunk...)
+	}
+	published <- []string{exchange, routingKey, string(body)}
+}
+
+func TestAMQPBroker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	published := make(chan []string, 1)
+	go serveAMQP(listener, published)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	broker, err := NewAMQPBroker(ctx, "amqp://"+listener.Addr().String())
+	require.NoError(t, err)
+	defer broker.Close(ctx)
+
+	// larger than the max frame size of the server, so the body is split into multiple frames
+	data := []byte(`"` + string(make([]byte, 5000)) + `"`)
+	for i := 1; i < len(data)-1; i++ {
+		data[i] = 'a'
+	}
+	assert.NoError(t, broker.Publish(ctx, Message{Topic: "discord.MESSAGE_CREATE.123", EventType: "MESSAGE_CREATE", Data: data}))
+	select {
+	case pub := <-published:
+		assert.Equal(t, "discord", pub[0])
+		assert.Equal(t, "discord.MESSAGE_CREATE.123", pub[1])
+		assert.Equal(t, `{"op":0,"s":0,"t":"MESSAGE_CREATE","shard_id":0,"d":`+string(data)+`}`, pub[2])
+	case <-ctx.Done():
+		t.Fatal("nothing published")
+	}
+
+	// a closed broker doesn't connect again
+	assert.NoError(t, broker.Close(ctx))
+	assert.ErrorIs(t, broker.Publish(ctx, Message{Topic: "discord.MESSAGE_CREATE.123", EventType: "MESSAGE_CREATE", Data: data}), ErrBrokerClosed)
+}
@@ -0,0 +1,70 @@
+package eventstream
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrBrokerClosed is returned when publishing to a closed Broker.
+var ErrBrokerClosed = errors.New("broker is closed")
+
+// ChannelBroker is a Broker which passes the Message(s) to a channel in the same process.
+type ChannelBroker interface {
+	Broker
+
+	// Messages returns the channel the Message(s) are sent to. It is closed when the ChannelBroker is closed.
+	Messages() <-chan Message
+}
+
+var _ ChannelBroker = (*channelBroker)(nil)
+
+// NewChannelBroker returns a ChannelBroker with the given buffer size. Publishing blocks while the buffer is full.
+func NewChannelBroker(bufferSize int) ChannelBroker {
+	return &channelBroker{
+		messages: make(chan Message, bufferSize),
+		closed:   make(chan struct{}),
+	}
+}
+
+type channelBroker struct {
+	messages chan Message
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	// mu prevents closing the messages channel while publishing
+	mu sync.RWMutex
+}
+
+func (b *channelBroker) Messages() <-chan Message {
+	return b.messages
+}
+
+func (b *channelBroker) Publish(ctx context.Context, message Message) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	select {
+	case <-b.closed:
+		return ErrBrokerClosed
+	default:
+	}
+
+	select {
+	case b.messages <- message:
+		return nil
+	case <-b.closed:
+		return ErrBrokerClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *channelBroker) Close(_ context.Context) error {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+		b.mu.Lock()
+		close(b.messages)
+		b.mu.Unlock()
+	})
+	return nil
+}
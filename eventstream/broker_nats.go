@@ -0,0 +1,279 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+// DefaultNATSConfig returns a NATSConfig with sensible defaults.
+func DefaultNATSConfig() *NATSConfig {
+	return &NATSConfig{
+		Logger: log.Default(),
+		Dialer: &net.Dialer{},
+		Name:   "disgo",
+	}
+}
+
+// NATSConfig lets you configure your NATS Broker instance.
+type NATSConfig struct {
+	Logger log.Logger
+	Dialer *net.Dialer
+	// Name is sent to the server to identify the connection.
+	Name string
+}
+
+// NATSConfigOpt is a type alias for a function that takes a NATSConfig and is used to configure your NATS Broker.
+type NATSConfigOpt func(config *NATSConfig)
+
+// Apply applies the given NATSConfigOpt(s) to the NATSConfig
+func (c *NATSConfig) Apply(opts []NATSConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithNATSLogger sets the logger of the NATS Broker.
+func WithNATSLogger(logger log.Logger) NATSConfigOpt {
+	return func(config *NATSConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithNATSDialer sets the net.Dialer used to connect to the NATS server.
+func WithNATSDialer(dialer *net.Dialer) NATSConfigOpt {
+	return func(config *NATSConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithNATSName sets the name which is sent to the NATS server to identify the connection.
+func WithNATSName(name string) NATSConfigOpt {
+	return func(config *NATSConfig) {
+		config.Name = name
+	}
+}
+
+var _ Broker = (*natsBroker)(nil)
+
+// NewNATSBroker connects to the NATS server at the given URL and returns a Broker which publishes each Message to the subject of its topic.
+// The URL has the format nats://[user:password@|token@]host[:port]. The connection is re-established on the next publish if it is lost, but not after the Broker is closed.
+// This speaks the plain NATS client protocol and does not support TLS, JetStream or clustering.
+func NewNATSBroker(ctx context.Context, natsURL string, opts ...NATSConfigOpt) (Broker, error) {
+	config := DefaultNATSConfig()
+	config.Apply(opts)
+
+	u, err := url.Parse(natsURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "nats" {
+		return nil, fmt.Errorf("unsupported nats url scheme: %s", u.Scheme)
+	}
+
+	b := &natsBroker{
+		url:    u,
+		config: *config,
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err = b.connect(ctx); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+type natsBroker struct {
+	url    *url.URL
+	config NATSConfig
+
+	conn   net.Conn
+	writer *bufio.Writer
+	closed bool
+	mu     sync.Mutex
+}
+
+// natsConnect is the payload of the CONNECT message.
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name,omitempty"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	Protocol  int    `json:"protocol"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// connect connects & authenticates to the NATS server. It must be called while holding mu.
+func (b *natsBroker) connect(ctx context.Context) error {
+	host := b.url.Host
+	if b.url.Port() == "" {
+		host = net.JoinHostPort(b.url.Hostname(), "4222")
+	}
+	conn, err := b.config.Dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+	if err = b.handshake(reader, writer); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	b.conn = conn
+	b.writer = writer
+	go b.read(conn, reader)
+	return nil
+}
+
+// handshake sends the CONNECT message and waits for the PONG of the following PING, which confirms the connection was accepted.
+func (b *natsBroker) handshake(reader *bufio.Reader, writer *bufio.Writer) error {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		return fmt.Errorf("unexpected nats message: %s", strings.TrimSpace(line))
+	}
+
+	connect := natsConnect{
+		Name:     b.config.Name,
+		Lang:     "go",
+		Version:  "disgo",
+		Protocol: 1,
+	}
+	if user := b.url.User; user != nil {
+		if pass, ok := user.Password(); ok {
+			connect.User = user.Username()
+			connect.Pass = pass
+		} else {
+			connect.AuthToken = user.Username()
+		}
+	}
+	data, err := json.Marshal(connect)
+	if err != nil {
+		return err
+	}
+	if _, err = fmt.Fprintf(writer, "CONNECT %s\r\nPING\r\n", data); err != nil {
+		return err
+	}
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	for {
+		line, err = reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("nats: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+// read answers the PINGs of the server until the connection is closed.
+func (b *natsBroker) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			b.mu.Lock()
+			if b.conn == conn {
+				b.config.Logger.Errorf("nats connection lost: %s", err)
+				_ = conn.Close()
+				b.conn = nil
+			}
+			b.mu.Unlock()
+			return
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PING":
+			b.mu.Lock()
+			if b.conn == conn {
+				_, _ = b.writer.WriteString("PONG\r\n")
+				_ = b.writer.Flush()
+			}
+			b.mu.Unlock()
+		case strings.HasPrefix(line, "-ERR"):
+			b.config.Logger.Errorf("nats: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (b *natsBroker) Publish(ctx context.Context, message Message) error {
+	if message.Topic == "" || strings.ContainsAny(message.Topic, " \t\r\n") {
+		return fmt.Errorf("invalid nats subject: %q", message.Topic)
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return ErrBrokerClosed
+	}
+	if b.conn == nil {
+		if err = b.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	deadline, _ := ctx.Deadline()
+	_ = b.conn.SetWriteDeadline(deadline)
+	if err = writeNATSPub(b.writer, message.Topic, data); err != nil {
+		_ = b.conn.Close()
+		b.conn = nil
+	}
+	return err
+}
+
+// writeNATSPub writes a PUB message with the given subject & payload.
+func writeNATSPub(writer *bufio.Writer, subject string, data []byte) error {
+	if _, err := fmt.Fprintf(writer, "PUB %s %d\r\n", subject, len(data)); err != nil {
+		return err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return err
+	}
+	if _, err := writer.WriteString("\r\n"); err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+func (b *natsBroker) Close(_ context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.conn == nil {
+		return nil
+	}
+	_ = b.writer.Flush()
+	err := b.conn.Close()
+	b.conn = nil
+	return err
+}
@@ -0,0 +1,77 @@
+package eventstream
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+func TestNATSBroker(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	published := make(chan string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "CONNECT "):
+				var connect natsConnect
+				if json.Unmarshal([]byte(strings.TrimPrefix(line, "CONNECT ")), &connect) != nil || connect.AuthToken != "secret" {
+					_, _ = conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+					return
+				}
+			case line == "PING\r\n":
+				_, _ = conn.Write([]byte("PONG\r\n"))
+			case strings.HasPrefix(line, "PUB "):
+				fields := strings.Fields(line)
+				size, _ := strconv.Atoi(fields[2])
+				payload := make([]byte, size+2)
+				if _, err = io.ReadFull(reader, payload); err != nil {
+					return
+				}
+				published <- fields[1] + " " + string(payload[:size])
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	broker, err := NewNATSBroker(ctx, "nats://secret@"+listener.Addr().String())
+	require.NoError(t, err)
+	defer broker.Close(ctx)
+
+	assert.NoError(t, broker.Publish(ctx, Message{Topic: "discord.READY.none", EventType: "READY", Data: []byte(`{}`)}))
+	select {
+	case pub := <-published:
+		assert.Equal(t, `discord.READY.none {"op":0,"s":0,"t":"READY","shard_id":0,"d":{}}`, pub)
+	case <-ctx.Done():
+		t.Fatal("nothing published")
+	}
+
+	// a closed broker doesn't connect again
+	assert.NoError(t, broker.Close(ctx))
+	assert.ErrorIs(t, broker.Publish(ctx, Message{Topic: "discord.READY.none", EventType: "READY", Data: []byte(`{}`)}), ErrBrokerClosed)
+}
@@ -0,0 +1,208 @@
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Message is a gateway dispatch published to a Broker.
+// It is encoded like a gateway.Message with the additional shard & guild id, so consumers like the relay of gateway.NewRelayCreateFunc can pass it on as is.
+type Message struct {
+	// Topic is the subject or routing key the Message is published to.
+	Topic string `json:"-"`
+
+	Op             gateway.Opcode    `json:"op"`
+	SequenceNumber int               `json:"s"`
+	EventType      gateway.EventType `json:"t"`
+	ShardID        int               `json:"shard_id"`
+	// GuildID is the guild the event belongs to or nil for events which do not belong to a guild.
+	GuildID *snowflake.ID   `json:"guild_id,omitempty"`
+	Data    json.RawMessage `json:"d"`
+}
+
+// Broker publishes Message(s) to a message broker.
+type Broker interface {
+	// Publish publishes the Message to its topic.
+	Publish(ctx context.Context, message Message) error
+
+	// Close closes the connection to the message broker.
+	Close(ctx context.Context) error
+}
+
+// TopicFunc returns the topic to publish the Message to.
+type TopicFunc func(message Message) string
+
+// TopicTemplate returns a TopicFunc which replaces {event_type}, {guild_id} & {shard_id} in the given template.
+// {guild_id} is replaced with none for events which do not belong to a guild. For example:
+//
+//	eventstream.TopicTemplate("discord.{event_type}.{guild_id}")
+//
+// publishes MESSAGE_CREATE events of the guild 123 to discord.MESSAGE_CREATE.123, which can be subscribed to with discord.MESSAGE_CREATE.* or discord.*.123.
+func TopicTemplate(template string) TopicFunc {
+	return func(message Message) string {
+		guildID := "none"
+		if message.GuildID != nil {
+			guildID = message.GuildID.String()
+		}
+		return strings.NewReplacer(
+			"{event_type}", string(message.EventType),
+			"{guild_id}", guildID,
+			"{shard_id}", strconv.Itoa(message.ShardID),
+		).Replace(template)
+	}
+}
+
+// Publisher publishes every raw gateway dispatch to a Broker.
+// The raw gateway events need to be enabled with gateway.WithEnableRawEvents.
+// The dispatches are queued & published in their own goroutine, so a slow Broker does not block the gateway. Dispatches received while the queue is full are dropped.
+type Publisher interface {
+	bot.EventListener
+
+	// EventHandlerFunc wraps the given gateway.EventHandlerFunc to publish the raw dispatches before passing all events on.
+	// Use this for processes which hold the gateway connections without a bot.Client.
+	EventHandlerFunc(next gateway.EventHandlerFunc) gateway.EventHandlerFunc
+
+	// Publish publishes the raw payload of a gateway dispatch without queueing it.
+	Publish(ctx context.Context, eventType gateway.EventType, sequenceNumber int, shardID int, data []byte) error
+
+	// Close stops the Publisher after all queued dispatches are published or the context is done. The Broker is not closed.
+	Close(ctx context.Context) error
+}
+
+var _ Publisher = (*publisherImpl)(nil)
+
+// New creates a new Publisher which publishes to the given Broker with the given ConfigOpt(s).
+func New(broker Broker, opts ...ConfigOpt) Publisher {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	p := &publisherImpl{
+		broker:  broker,
+		config:  *config,
+		queue:   make(chan Message, config.QueueSize),
+		stopped: make(chan struct{}),
+	}
+	go p.loop()
+	return p
+}
+
+type publisherImpl struct {
+	broker Broker
+	config Config
+
+	queue  chan Message
+	closed bool
+	// mu prevents closing the queue while queueing
+	mu      sync.RWMutex
+	stopped chan struct{}
+}
+
+func (p *publisherImpl) loop() {
+	defer close(p.stopped)
+	for message := range p.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.PublishTimeout)
+		if err := p.broker.Publish(ctx, message); err != nil {
+			p.config.Logger.Errorf("failed to publish %s event: %s", message.EventType, err)
+		}
+		cancel()
+	}
+}
+
+func (p *publisherImpl) OnEvent(event bot.Event) {
+	if e, ok := event.(*events.Raw); ok {
+		// the payload can only be read once, so we pass a fresh one on to the other listeners
+		e.EventRaw = p.publishRaw(e.ShardID(), e.SequenceNumber(), e.EventRaw)
+	}
+}
+
+func (p *publisherImpl) EventHandlerFunc(next gateway.EventHandlerFunc) gateway.EventHandlerFunc {
+	return func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		if raw, ok := event.(gateway.EventRaw); ok {
+			event = p.publishRaw(shardID, sequenceNumber, raw)
+		}
+		next(eventType, sequenceNumber, shardID, event)
+	}
+}
+
+// publishRaw queues the gateway.EventRaw to be published and returns it with an unread payload.
+func (p *publisherImpl) publishRaw(shardID int, sequenceNumber int, raw gateway.EventRaw) gateway.EventRaw {
+	data, err := io.ReadAll(raw.Payload)
+	if err != nil {
+		p.config.Logger.Errorf("failed to read raw %s event: %s", raw.EventType, err)
+		return raw
+	}
+	raw.Payload = bytes.NewReader(data)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		p.config.Logger.Warnf("dropped %s event as the publisher is closed", raw.EventType)
+		return raw
+	}
+	select {
+	case p.queue <- p.message(raw.EventType, sequenceNumber, shardID, data):
+	default:
+		p.config.Logger.Warnf("dropped %s event as the publish queue is full", raw.EventType)
+	}
+	return raw
+}
+
+func (p *publisherImpl) Publish(ctx context.Context, eventType gateway.EventType, sequenceNumber int, shardID int, data []byte) error {
+	return p.broker.Publish(ctx, p.message(eventType, sequenceNumber, shardID, data))
+}
+
+func (p *publisherImpl) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// message returns the Message of a raw gateway dispatch.
+func (p *publisherImpl) message(eventType gateway.EventType, sequenceNumber int, shardID int, data []byte) Message {
+	message := Message{
+		Op:             gateway.OpcodeDispatch,
+		SequenceNumber: sequenceNumber,
+		EventType:      eventType,
+		ShardID:        shardID,
+		GuildID:        guildIDFromEvent(eventType, data),
+		Data:           data,
+	}
+	message.Topic = p.config.TopicFunc(message)
+	return message
+}
+
+// guildIDFromEvent returns the id of the guild the raw event belongs to or nil if it does not belong to one.
+func guildIDFromEvent(eventType gateway.EventType, data []byte) *snowflake.ID {
+	var v struct {
+		ID      *snowflake.ID `json:"id"`
+		GuildID *snowflake.ID `json:"guild_id"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+	switch eventType {
+	case gateway.EventTypeGuildCreate, gateway.EventTypeGuildUpdate, gateway.EventTypeGuildDelete:
+		return v.ID
+	}
+	return v.GuildID
+}
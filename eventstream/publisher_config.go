@@ -0,0 +1,72 @@
+package eventstream
+
+import (
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:         log.Default(),
+		TopicFunc:      TopicTemplate("discord.{event_type}.{guild_id}"),
+		PublishTimeout: 5 * time.Second,
+		QueueSize:      1000,
+	}
+}
+
+// Config lets you configure your Publisher instance.
+type Config struct {
+	Logger    log.Logger
+	TopicFunc TopicFunc
+
+	// PublishTimeout is how long publishing an event may take before it is dropped.
+	PublishTimeout time.Duration
+
+	// QueueSize is how many raw gateway dispatches can wait to be published. Dispatches received while the queue is full are dropped.
+	QueueSize int
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Publisher.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the logger of the Publisher.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithTopicFunc sets the TopicFunc which returns the topic a Message is published to.
+func WithTopicFunc(topicFunc TopicFunc) ConfigOpt {
+	return func(config *Config) {
+		config.TopicFunc = topicFunc
+	}
+}
+
+// WithTopicTemplate sets the template of the topic a Message is published to. See TopicTemplate for the placeholders.
+func WithTopicTemplate(template string) ConfigOpt {
+	return WithTopicFunc(TopicTemplate(template))
+}
+
+// WithPublishTimeout sets how long publishing an event may take before it is dropped.
+func WithPublishTimeout(publishTimeout time.Duration) ConfigOpt {
+	return func(config *Config) {
+		config.PublishTimeout = publishTimeout
+	}
+}
+
+// WithQueueSize sets how many raw gateway dispatches can wait to be published before further ones are dropped.
+func WithQueueSize(queueSize int) ConfigOpt {
+	return func(config *Config) {
+		config.QueueSize = queueSize
+	}
+}
@@ -0,0 +1,104 @@
+package eventstream
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func TestTopicTemplate(t *testing.T) {
+	topicFunc := TopicTemplate("discord.{shard_id}.{event_type}.{guild_id}")
+	guildID := snowflake.ID(123)
+
+	assert.Equal(t, "discord.1.MESSAGE_CREATE.123", topicFunc(Message{EventType: gateway.EventTypeMessageCreate, ShardID: 1, GuildID: &guildID}))
+	assert.Equal(t, "discord.0.USER_UPDATE.none", topicFunc(Message{EventType: gateway.EventTypeUserUpdate}))
+}
+
+func TestPublisher_EventHandlerFunc(t *testing.T) {
+	broker := NewChannelBroker(2)
+	publisher := New(broker)
+
+	var payloads []string
+	eventHandlerFunc := publisher.EventHandlerFunc(func(_ gateway.EventType, _ int, _ int, event gateway.EventData) {
+		data, _ := io.ReadAll(event.(gateway.EventRaw).Payload)
+		payloads = append(payloads, string(data))
+	})
+
+	eventHandlerFunc(gateway.EventTypeRaw, 3, 1, gateway.EventRaw{
+		EventType: gateway.EventTypeMessageCreate,
+		Payload:   bytes.NewReader([]byte(`{"id":"1","guild_id":"123"}`)),
+	})
+	eventHandlerFunc(gateway.EventTypeRaw, 4, 1, gateway.EventRaw{
+		EventType: gateway.EventTypeGuildCreate,
+		Payload:   bytes.NewReader([]byte(`{"id":"456"}`)),
+	})
+	assert.Equal(t, []string{`{"id":"1","guild_id":"123"}`, `{"id":"456"}`}, payloads)
+
+	message := <-broker.Messages()
+	assert.Equal(t, "discord.MESSAGE_CREATE.123", message.Topic)
+	assert.Equal(t, 3, message.SequenceNumber)
+	assert.Equal(t, 1, message.ShardID)
+
+	message = <-broker.Messages()
+	assert.Equal(t, "discord.GUILD_CREATE.456", message.Topic)
+
+	assert.NoError(t, publisher.Close(context.Background()))
+	assert.NoError(t, broker.Close(context.Background()))
+	assert.ErrorIs(t, broker.Publish(context.Background(), message), ErrBrokerClosed)
+}
+
+// blockingBroker blocks publishing until it is released.
+type blockingBroker struct {
+	published chan Message
+	release   chan struct{}
+}
+
+func (b *blockingBroker) Publish(_ context.Context, message Message) error {
+	b.published <- message
+	<-b.release
+	return nil
+}
+
+func (b *blockingBroker) Close(_ context.Context) error {
+	return nil
+}
+
+func TestPublisher_QueueFull(t *testing.T) {
+	broker := &blockingBroker{published: make(chan Message, 3), release: make(chan struct{})}
+	publisher := New(broker, WithQueueSize(1))
+
+	var dispatched int
+	eventHandlerFunc := publisher.EventHandlerFunc(func(_ gateway.EventType, _ int, _ int, _ gateway.EventData) {
+		dispatched++
+	})
+	dispatch := func(sequenceNumber int) {
+		eventHandlerFunc(gateway.EventTypeRaw, sequenceNumber, 0, gateway.EventRaw{
+			EventType: gateway.EventTypeMessageCreate,
+			Payload:   bytes.NewReader([]byte(`{"id":"1"}`)),
+		})
+	}
+
+	dispatch(1)
+	// the first dispatch is being published, so the second one fills the queue & the third one is dropped
+	assert.Equal(t, 1, (<-broker.published).SequenceNumber)
+	dispatch(2)
+	dispatch(3)
+	// the gateway is never blocked by the broker
+	assert.Equal(t, 3, dispatched)
+
+	close(broker.release)
+	assert.NoError(t, publisher.Close(context.Background()))
+	close(broker.published)
+
+	var published []int
+	for message := range broker.published {
+		published = append(published, message.SequenceNumber)
+	}
+	assert.Equal(t, []int{2}, published)
+}
@@ -2,6 +2,7 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/disgoorg/log"
@@ -16,13 +17,39 @@ type Status int
 // IsConnected returns whether the Gateway is connected.
 func (s Status) IsConnected() bool {
 	switch s {
-	case StatusWaitingForHello, StatusIdentifying, StatusWaitingForReady, StatusReady:
+	case StatusWaitingForHello, StatusIdentifying, StatusResuming, StatusWaitingForReady, StatusReady:
 		return true
 	default:
 		return false
 	}
 }
 
+// String returns a human-readable name of the Status.
+func (s Status) String() string {
+	switch s {
+	case StatusUnconnected:
+		return "unconnected"
+	case StatusConnecting:
+		return "connecting"
+	case StatusWaitingForHello:
+		return "waiting for hello"
+	case StatusIdentifying:
+		return "identifying"
+	case StatusResuming:
+		return "resuming"
+	case StatusWaitingForReady:
+		return "waiting for ready"
+	case StatusReady:
+		return "ready"
+	case StatusDisconnected:
+		return "disconnected"
+	case StatusZombied:
+		return "zombied"
+	default:
+		return fmt.Sprintf("unknown (%d)", int(s))
+	}
+}
+
 // Indicates how far along the client is too connecting.
 const (
 	// StatusUnconnected is the initial state when a new Gateway is created.
@@ -49,6 +76,10 @@ const (
 	// StatusDisconnected is the state when the Gateway is disconnected.
 	// Either due to an error or because the Gateway was closed gracefully.
 	StatusDisconnected
+
+	// StatusZombied is the state when the Gateway did not receive a OpcodeHeartbeatACK for its last OpcodeHeartbeat.
+	// The connection is closed with a resumable close code and reconnected afterwards.
+	StatusZombied
 )
 
 type (
@@ -60,6 +91,10 @@ type (
 
 	// CloseHandlerFunc is a function that is called when the Gateway is closed.
 	CloseHandlerFunc func(gateway Gateway, err error)
+
+	// StatusChangeHandlerFunc is a function that is called when the Status of the Gateway changes.
+	// It is called synchronously in the order the changes happen, so it should not block.
+	StatusChangeHandlerFunc func(gateway Gateway, event EventStatusChange)
 )
 
 // Gateway is what is used to connect to discord.
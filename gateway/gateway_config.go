@@ -36,8 +36,11 @@ type Config struct {
 	AutoReconnect             bool
 	MaxReconnectTries         int
 	EnableRawEvents           bool
+	StatusChangeHandlerFunc   StatusChangeHandlerFunc
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
+	IdentifyRateLimiter       IdentifyRateLimiter
+	Recorder                  Recorder
 	Presence                  *MessageDataPresenceUpdate
 	OS                        string
 	Browser                   string
@@ -155,6 +158,20 @@ func WithEnableRawEvents(enableRawEventEvents bool) ConfigOpt {
 	}
 }
 
+// WithStatusChangeHandler sets the StatusChangeHandlerFunc which is called when the Status of the Gateway changes.
+func WithStatusChangeHandler(statusChangeHandlerFunc StatusChangeHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.StatusChangeHandlerFunc = statusChangeHandlerFunc
+	}
+}
+
+// WithRecorder sets the Recorder which records all messages received & sent by the Gateway.
+func WithRecorder(recorder Recorder) ConfigOpt {
+	return func(config *Config) {
+		config.Recorder = recorder
+	}
+}
+
 // WithRateLimiter sets the grate.RateLimiter for the Gateway.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
@@ -169,6 +186,14 @@ func WithRateRateLimiterConfigOpts(opts ...RateLimiterConfigOpt) ConfigOpt {
 	}
 }
 
+// WithIdentifyRateLimiter sets the IdentifyRateLimiter which is waited for before identifying.
+// Resuming a session does not wait for it. By default, identifies are not limited.
+func WithIdentifyRateLimiter(identifyRateLimiter IdentifyRateLimiter) ConfigOpt {
+	return func(config *Config) {
+		config.IdentifyRateLimiter = identifyRateLimiter
+	}
+}
+
 // WithPresence sets the initial presence the bot should display.
 func WithPresence(presence MessageDataPresenceUpdate) ConfigOpt {
 	return func(config *Config) {
@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/gorilla/websocket"
+)
+
+// connStatus holds the Status of a Gateway implementation and reconnects it.
+// It notifies the StatusChangeHandlerFunc & EventHandlerFunc of the Gateway about every Status change.
+type connStatus struct {
+	gateway          Gateway
+	config           *Config
+	eventHandlerFunc EventHandlerFunc
+	// logPrefix is prepended to all logs of the Gateway after the shard.
+	logPrefix string
+	// closeConn closes the current connection with the code & message and returns whether there was one.
+	closeConn func(ctx context.Context, code int, message string) bool
+	// reconnectDelay is multiplied with the number of failed reconnect attempts.
+	reconnectDelay time.Duration
+
+	status           Status
+	reconnectAttempt int
+	statusMu         sync.Mutex
+}
+
+func newConnStatus(gateway Gateway, config *Config, eventHandlerFunc EventHandlerFunc) *connStatus {
+	return &connStatus{
+		gateway:          gateway,
+		config:           config,
+		eventHandlerFunc: eventHandlerFunc,
+		status:           StatusUnconnected,
+	}
+}
+
+func (s *connStatus) formatLogsf(format string, a ...any) string {
+	return s.formatLogs(fmt.Sprintf(format, a...))
+}
+
+func (s *connStatus) formatLogs(a ...any) string {
+	if s.config.ShardCount > 1 {
+		return fmt.Sprintf("[%d/%d] %s%s", s.config.ShardID, s.config.ShardCount, s.logPrefix, fmt.Sprint(a...))
+	}
+	return s.logPrefix + fmt.Sprint(a...)
+}
+
+func (s *connStatus) Status() Status {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	return s.status
+}
+
+// setStatus sets the Status and notifies the StatusChangeHandlerFunc & EventHandlerFunc if it changed.
+// It must not be called while holding the connection mutex of the Gateway, as the handlers might use the Gateway.
+func (s *connStatus) setStatus(status Status, cause error) {
+	s.statusMu.Lock()
+	oldStatus := s.status
+	s.status = status
+	attempt := s.reconnectAttempt
+	if status == StatusReady {
+		s.reconnectAttempt = 0
+	}
+	s.statusMu.Unlock()
+
+	if oldStatus == status {
+		return
+	}
+	event := EventStatusChange{
+		OldStatus: oldStatus,
+		Status:    status,
+		Cause:     cause,
+		Attempt:   attempt,
+	}
+	if s.config.StatusChangeHandlerFunc != nil {
+		s.config.StatusChangeHandlerFunc(s.gateway, event)
+	}
+	s.eventHandlerFunc(EventTypeStatusChange, s.sequenceNumber(), s.config.ShardID, event)
+}
+
+// sequenceNumber returns the last sequence number received or 0 if none was received yet.
+func (s *connStatus) sequenceNumber() int {
+	if s.config.LastSequenceReceived == nil {
+		return 0
+	}
+	return *s.config.LastSequenceReceived
+}
+
+// closeWithCause closes the connection like CloseWithCode and passes the cause on to the StatusChangeHandlerFunc.
+func (s *connStatus) closeWithCause(ctx context.Context, code int, message string, cause error) {
+	if !s.closeConn(ctx, code, message) {
+		return
+	}
+	// clear resume data as we closed gracefully
+	if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
+		s.config.SessionID = nil
+		s.config.LastSequenceReceived = nil
+	}
+	s.setStatus(StatusDisconnected, cause)
+}
+
+func (s *connStatus) reconnectTry(ctx context.Context, try int) error {
+	if try >= s.config.MaxReconnectTries-1 {
+		return fmt.Errorf("failed to reconnect. exceeded max reconnect tries of %d reached", s.config.MaxReconnectTries)
+	}
+	timer := time.NewTimer(time.Duration(try) * s.reconnectDelay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+	}
+
+	s.statusMu.Lock()
+	s.reconnectAttempt = try + 1
+	s.statusMu.Unlock()
+
+	s.gateway.Logger().Debug(s.formatLogs("reconnecting..."))
+	if err := s.gateway.Open(ctx); err != nil {
+		if err == discord.ErrGatewayAlreadyConnected {
+			return err
+		}
+		s.gateway.Logger().Error(s.formatLogs("failed to reconnect. error: ", err))
+		return s.reconnectTry(ctx, try+1)
+	}
+	return nil
+}
+
+func (s *connStatus) reconnect(ctx context.Context) {
+	if err := s.reconnectTry(ctx, 0); err != nil {
+		s.gateway.Logger().Error(s.formatLogs("failed to reopen connection. error: ", err))
+	}
+}
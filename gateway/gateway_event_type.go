@@ -68,3 +68,11 @@ const (
 	EventTypeVoiceServerUpdate                   EventType = "VOICE_SERVER_UPDATE"
 	EventTypeWebhooksUpdate                      EventType = "WEBHOOKS_UPDATE"
 )
+
+// Constants for the events which are created by the Gateway itself
+const (
+	// EventTypeZombied is not a real event type, but is used to tell the bot.EventManager that the Gateway missed a heartbeat ACK and is reconnecting
+	EventTypeZombied EventType = "__ZOMBIED__"
+	// EventTypeStatusChange is not a real event type, but is used to tell the bot.EventManager that the Status of the Gateway changed
+	EventTypeStatusChange EventType = "__STATUS_CHANGE__"
+)
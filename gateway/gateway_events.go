@@ -604,3 +604,25 @@ type EventRaw struct {
 
 func (EventRaw) messageData() {}
 func (EventRaw) eventData()   {}
+
+// EventZombied is sent when the Gateway did not receive a heartbeat ACK since the last heartbeat and closes the connection to resume it.
+type EventZombied struct {
+	LastHeartbeatSent     time.Time
+	LastHeartbeatReceived time.Time
+}
+
+func (EventZombied) messageData() {}
+func (EventZombied) eventData()   {}
+
+// EventStatusChange is sent when the Status of the Gateway changes.
+type EventStatusChange struct {
+	OldStatus Status
+	Status    Status
+	// Cause is the error which caused the change or nil if it was expected.
+	Cause error
+	// Attempt is the number of the current reconnect attempt or 0 if the Gateway is not reconnecting.
+	Attempt int
+}
+
+func (EventStatusChange) messageData() {}
+func (EventStatusChange) eventData()   {}
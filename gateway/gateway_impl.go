@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net"
 	"sync"
 	"time"
@@ -26,16 +27,21 @@ func New(token string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc Close
 	config := DefaultConfig()
 	config.Apply(opts)
 
-	return &gatewayImpl{
+	g := &gatewayImpl{
 		config:           *config,
 		eventHandlerFunc: eventHandlerFunc,
 		closeHandlerFunc: closeHandlerFunc,
 		token:            token,
-		status:           StatusUnconnected,
 	}
+	g.connStatus = newConnStatus(g, &g.config, eventHandlerFunc)
+	g.closeConn = g.closeConnection
+	g.reconnectDelay = time.Second
+	return g
 }
 
 type gatewayImpl struct {
+	*connStatus
+
 	config           Config
 	eventHandlerFunc EventHandlerFunc
 	closeHandlerFunc CloseHandlerFunc
@@ -43,10 +49,10 @@ type gatewayImpl struct {
 
 	conn            *websocket.Conn
 	connMu          sync.Mutex
-	heartbeatTicker *time.Ticker
-	status          Status
+	heartbeatCancel context.CancelFunc
 
 	heartbeatInterval     time.Duration
+	heartbeatMu           sync.Mutex
 	lastHeartbeatSent     time.Time
 	lastHeartbeatReceived time.Time
 }
@@ -75,35 +81,23 @@ func (g *gatewayImpl) Intents() Intents {
 	return g.config.Intents
 }
 
-func (g *gatewayImpl) formatLogsf(format string, a ...any) string {
-	if g.config.ShardCount > 1 {
-		return fmt.Sprintf("[%d/%d] %s", g.config.ShardID, g.config.ShardCount, fmt.Sprintf(format, a...))
-	}
-	return fmt.Sprintf(format, a...)
-}
-
-func (g *gatewayImpl) formatLogs(a ...any) string {
-	if g.config.ShardCount > 1 {
-		return fmt.Sprintf("[%d/%d] %s", g.config.ShardID, g.config.ShardCount, fmt.Sprint(a...))
-	}
-	return fmt.Sprint(a...)
-}
-
 func (g *gatewayImpl) Open(ctx context.Context) error {
 	g.Logger().Debug(g.formatLogs("opening gateway connection"))
 
 	g.connMu.Lock()
-	defer g.connMu.Unlock()
-	if g.conn != nil {
+	connected := g.conn != nil
+	g.connMu.Unlock()
+	if connected {
 		return discord.ErrGatewayAlreadyConnected
 	}
-	g.status = StatusConnecting
+	g.setStatus(StatusConnecting, nil)
 
 	gatewayURL := fmt.Sprintf("%s?v=%d&encoding=json", g.config.URL, Version)
+	g.heartbeatMu.Lock()
 	g.lastHeartbeatSent = time.Now().UTC()
+	g.heartbeatMu.Unlock()
 	conn, rs, err := g.config.Dialer.DialContext(ctx, gatewayURL, nil)
 	if err != nil {
-		g.Close(ctx)
 		body := "null"
 		if rs != nil && rs.Body != nil {
 			defer func() {
@@ -117,6 +111,7 @@ func (g *gatewayImpl) Open(ctx context.Context) error {
 		}
 
 		g.Logger().Error(g.formatLogsf("error connecting to the gateway. url: %s, error: %s, body: %s", gatewayURL, err, body))
+		g.setStatus(StatusDisconnected, err)
 		return err
 	}
 
@@ -124,12 +119,20 @@ func (g *gatewayImpl) Open(ctx context.Context) error {
 		return nil
 	})
 
+	g.connMu.Lock()
+	if g.conn != nil {
+		// another Open call connected in the meantime
+		g.connMu.Unlock()
+		_ = conn.Close()
+		return discord.ErrGatewayAlreadyConnected
+	}
 	g.conn = conn
 
 	// reset rate limiter when connecting
 	g.config.RateLimiter.Reset()
+	g.connMu.Unlock()
 
-	g.status = StatusWaitingForHello
+	g.setStatus(StatusWaitingForHello, nil)
 
 	go g.listen(conn)
 
@@ -141,36 +144,29 @@ func (g *gatewayImpl) Close(ctx context.Context) {
 }
 
 func (g *gatewayImpl) CloseWithCode(ctx context.Context, code int, message string) {
-	if g.heartbeatTicker != nil {
-		g.Logger().Debug(g.formatLogs("closing heartbeat goroutines..."))
-		g.heartbeatTicker.Stop()
-		g.heartbeatTicker = nil
-	}
-
-	g.connMu.Lock()
-	defer g.connMu.Unlock()
-	if g.conn != nil {
-		g.config.RateLimiter.Close(ctx)
-		g.Logger().Debug(g.formatLogsf("closing gateway connection with code: %d, message: %s", code, message))
-		if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && err != websocket.ErrCloseSent {
-			g.Logger().Debug(g.formatLogs("error writing close code. error: ", err))
-		}
-		_ = g.conn.Close()
-		g.conn = nil
-
-		// clear resume data as we closed gracefully
-		if code == websocket.CloseNormalClosure || code == websocket.CloseGoingAway {
-			g.config.SessionID = nil
-			g.config.LastSequenceReceived = nil
-		}
-	}
-
+	g.closeWithCause(ctx, code, message, nil)
 }
 
-func (g *gatewayImpl) Status() Status {
+// closeConnection stops the heartbeat & closes the websocket connection. It returns whether there was a connection to close.
+func (g *gatewayImpl) closeConnection(ctx context.Context, code int, message string) bool {
 	g.connMu.Lock()
 	defer g.connMu.Unlock()
-	return g.status
+	if g.heartbeatCancel != nil {
+		g.Logger().Debug(g.formatLogs("closing heartbeat goroutines..."))
+		g.heartbeatCancel()
+		g.heartbeatCancel = nil
+	}
+	if g.conn == nil {
+		return false
+	}
+	g.config.RateLimiter.Close(ctx)
+	g.Logger().Debug(g.formatLogsf("closing gateway connection with code: %d, message: %s", code, message))
+	if err := g.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && err != websocket.ErrCloseSent {
+		g.Logger().Debug(g.formatLogs("error writing close code. error: ", err))
+	}
+	_ = g.conn.Close()
+	g.conn = nil
+	return true
 }
 
 func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error {
@@ -181,7 +177,34 @@ func (g *gatewayImpl) Send(ctx context.Context, op Opcode, d MessageData) error
 	if err != nil {
 		return err
 	}
-	return g.send(ctx, websocket.TextMessage, data)
+	if err = g.send(ctx, websocket.TextMessage, data); err != nil {
+		return err
+	}
+	if g.config.Recorder != nil {
+		rawD, err := json.Marshal(redactToken(d))
+		if err != nil {
+			return err
+		}
+		g.record(RecordDirectionSend, Message{Op: op, RawD: rawD})
+	}
+	return nil
+}
+
+// record passes the Message to the Recorder if one is configured.
+func (g *gatewayImpl) record(direction RecordDirection, message Message) {
+	if g.config.Recorder == nil {
+		return
+	}
+	if err := g.config.Recorder.Record(RecordedMessage{
+		Time:      time.Now().UTC(),
+		Direction: direction,
+		Op:        message.Op,
+		S:         message.S,
+		T:         message.T,
+		D:         message.RawD,
+	}); err != nil {
+		g.Logger().Error(g.formatLogs("failed to record gateway message. error: ", err))
+	}
 }
 
 func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) error {
@@ -201,48 +224,66 @@ func (g *gatewayImpl) send(ctx context.Context, messageType int, data []byte) er
 }
 
 func (g *gatewayImpl) Latency() time.Duration {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
 	return g.lastHeartbeatReceived.Sub(g.lastHeartbeatSent)
 }
 
-func (g *gatewayImpl) reconnectTry(ctx context.Context, try int, delay time.Duration) error {
-	if try >= g.config.MaxReconnectTries-1 {
-		return fmt.Errorf("failed to reconnect. exceeded max reconnect tries of %d reached", g.config.MaxReconnectTries)
-	}
-	timer := time.NewTimer(time.Duration(try) * delay)
+func (g *gatewayImpl) heartbeat(ctx context.Context, interval time.Duration) {
+	defer g.Logger().Debug(g.formatLogs("exiting heartbeat goroutine..."))
+
+	// discord wants the first heartbeat to be sent after interval * jitter, so not all clients heartbeat at the same time after an outage
+	timer := time.NewTimer(time.Duration(rand.Float64() * float64(interval)))
 	defer timer.Stop()
 	select {
 	case <-ctx.Done():
-		timer.Stop()
-		return ctx.Err()
+		return
 	case <-timer.C:
+		g.sendHeartbeat()
 	}
 
-	g.Logger().Debug(g.formatLogs("reconnecting gateway..."))
-	if err := g.Open(ctx); err != nil {
-		if err == discord.ErrGatewayAlreadyConnected {
-			return err
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !g.heartbeatAcked() {
+				g.zombied()
+				return
+			}
+			g.sendHeartbeat()
 		}
-		g.Logger().Error(g.formatLogs("failed to reconnect gateway. error: ", err))
-		g.status = StatusDisconnected
-		return g.reconnectTry(ctx, try+1, delay)
 	}
-	return nil
 }
 
-func (g *gatewayImpl) reconnect(ctx context.Context) {
-	err := g.reconnectTry(ctx, 0, time.Second)
-	if err != nil {
-		g.Logger().Error(g.formatLogs("failed to reopen gateway. error: ", err))
-	}
+// heartbeatAcked returns whether a OpcodeHeartbeatACK was received since the last OpcodeHeartbeat was sent.
+func (g *gatewayImpl) heartbeatAcked() bool {
+	g.heartbeatMu.Lock()
+	defer g.heartbeatMu.Unlock()
+	return !g.lastHeartbeatReceived.Before(g.lastHeartbeatSent)
 }
 
-func (g *gatewayImpl) heartbeat() {
-	g.heartbeatTicker = time.NewTicker(g.heartbeatInterval)
-	defer g.heartbeatTicker.Stop()
-	defer g.Logger().Debug(g.formatLogs("exiting heartbeat goroutine..."))
+// zombied closes the connection with a resumable close code and reconnects, as the connection has most likely died without being closed.
+func (g *gatewayImpl) zombied() {
+	g.heartbeatMu.Lock()
+	event := EventZombied{
+		LastHeartbeatSent:     g.lastHeartbeatSent,
+		LastHeartbeatReceived: g.lastHeartbeatReceived,
+	}
+	g.heartbeatMu.Unlock()
 
-	for range g.heartbeatTicker.C {
-		g.sendHeartbeat()
+	g.Logger().Warn(g.formatLogsf("no heartbeat ACK received since the last heartbeat at %s. reconnecting...", event.LastHeartbeatSent))
+
+	g.setStatus(StatusZombied, discord.ErrGatewayZombied)
+	g.eventHandlerFunc(EventTypeZombied, g.sequenceNumber(), g.config.ShardID, event)
+
+	g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "heartbeat ACK not received", discord.ErrGatewayZombied)
+	if g.config.AutoReconnect {
+		go g.reconnect(context.TODO())
+	} else if g.closeHandlerFunc != nil {
+		go g.closeHandlerFunc(g, discord.ErrGatewayZombied)
 	}
 }
 
@@ -251,17 +292,32 @@ func (g *gatewayImpl) sendHeartbeat() {
 
 	ctx, cancel := context.WithTimeout(context.Background(), g.heartbeatInterval)
 	defer cancel()
+	// remember the time before sending, so an ACK received right after is never older than the heartbeat
+	sent := time.Now().UTC()
 	if err := g.Send(ctx, OpcodeHeartbeat, (*MessageDataHeartbeat)(g.config.LastSequenceReceived)); err != nil && err != discord.ErrShardNotConnected {
 		g.Logger().Error(g.formatLogs("failed to send heartbeat. error: ", err))
-		g.CloseWithCode(context.TODO(), websocket.CloseServiceRestart, "heartbeat timeout")
+		g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "heartbeat timeout", err)
 		go g.reconnect(context.TODO())
 		return
 	}
-	g.lastHeartbeatSent = time.Now().UTC()
+	g.heartbeatMu.Lock()
+	g.lastHeartbeatSent = sent
+	g.heartbeatMu.Unlock()
 }
 
-func (g *gatewayImpl) identify() {
-	g.status = StatusIdentifying
+func (g *gatewayImpl) identify(ctx context.Context) {
+	g.setStatus(StatusIdentifying, nil)
+
+	if rateLimiter := g.config.IdentifyRateLimiter; rateLimiter != nil {
+		g.Logger().Debug(g.formatLogs("waiting for identify rate limiter..."))
+		if err := rateLimiter.WaitBucket(ctx, g.ShardID()); err != nil {
+			// the connection was closed while waiting
+			g.Logger().Debug(g.formatLogs("failed to wait for identify rate limiter. error: ", err))
+			return
+		}
+		defer rateLimiter.UnlockBucket(g.ShardID())
+	}
+
 	g.Logger().Debug(g.formatLogs("sending Identify command..."))
 
 	identify := MessageDataIdentify{
@@ -280,14 +336,15 @@ func (g *gatewayImpl) identify() {
 		identify.Shard = &[2]int{g.ShardID(), g.ShardCount()}
 	}
 
-	if err := g.Send(context.TODO(), OpcodeIdentify, identify); err != nil {
+	// set the status before sending, as the ready event is received by the listen goroutine
+	g.setStatus(StatusWaitingForReady, nil)
+	if err := g.Send(ctx, OpcodeIdentify, identify); err != nil {
 		g.Logger().Error(g.formatLogs("error sending Identify command err: ", err))
 	}
-	g.status = StatusWaitingForReady
 }
 
 func (g *gatewayImpl) resume() {
-	g.status = StatusResuming
+	g.setStatus(StatusResuming, nil)
 	resume := MessageDataResume{
 		Token:     g.token,
 		SessionID: *g.config.SessionID,
@@ -343,9 +400,11 @@ loop:
 			}
 
 			if g.config.AutoReconnect && reconnect {
+				// close the dead connection without dropping the session, so we can resume it
+				g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "reconnecting", err)
 				go g.reconnect(context.TODO())
 			} else {
-				g.Close(context.TODO())
+				g.closeWithCause(context.TODO(), websocket.CloseNormalClosure, "Shutting down", err)
 				if g.closeHandlerFunc != nil {
 					go g.closeHandlerFunc(g, err)
 				}
@@ -358,16 +417,24 @@ loop:
 			g.Logger().Error(g.formatLogs("error while parsing gateway message. error: ", err))
 			continue
 		}
+		g.record(RecordDirectionReceive, event)
 
 		switch event.Op {
 		case OpcodeHello:
+			g.heartbeatMu.Lock()
 			g.lastHeartbeatReceived = time.Now().UTC()
-			go g.heartbeat()
+			g.heartbeatMu.Unlock()
 
 			g.heartbeatInterval = time.Duration(event.D.(MessageDataHello).HeartbeatInterval) * time.Millisecond
+			heartbeatCtx, cancel := context.WithCancel(context.Background())
+			g.connMu.Lock()
+			g.heartbeatCancel = cancel
+			g.connMu.Unlock()
+			go g.heartbeat(heartbeatCtx, g.heartbeatInterval)
 
 			if g.config.LastSequenceReceived == nil || g.config.SessionID == nil {
-				g.identify()
+				// identifying might have to wait for the IdentifyRateLimiter, so we keep reading heartbeat ACKs meanwhile
+				go g.identify(heartbeatCtx)
 			} else {
 				g.resume()
 			}
@@ -387,8 +454,11 @@ loop:
 			// get session id here
 			if readyEvent, ok := data.(EventReady); ok {
 				g.config.SessionID = &readyEvent.SessionID
-				g.status = StatusReady
+				g.setStatus(StatusReady, nil)
 				g.Logger().Debug(g.formatLogs("ready event received"))
+			} else if event.T == EventTypeResumed {
+				g.setStatus(StatusReady, nil)
+				g.Logger().Debug(g.formatLogs("resumed event received"))
 			}
 
 			// push event to the command manager
@@ -406,7 +476,7 @@ loop:
 
 		case OpcodeReconnect:
 			g.Logger().Debug(g.formatLogs("received: OpcodeReconnect"))
-			g.CloseWithCode(context.TODO(), websocket.CloseServiceRestart, "received reconnect")
+			g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "received reconnect", discord.ErrGatewayReconnect)
 			go g.reconnect(context.TODO())
 			break loop
 
@@ -423,13 +493,15 @@ loop:
 				g.config.LastSequenceReceived = nil
 			}
 
-			g.CloseWithCode(context.TODO(), code, "invalid session")
+			g.closeWithCause(context.TODO(), code, "invalid session", discord.ErrGatewayInvalidSession)
 			go g.reconnect(context.TODO())
 			break loop
 
 		case OpcodeHeartbeatACK:
 			g.Logger().Debug(g.formatLogs("received: OpcodeHeartbeatACK"))
+			g.heartbeatMu.Lock()
 			g.lastHeartbeatReceived = time.Now().UTC()
+			g.heartbeatMu.Unlock()
 		}
 	}
 }
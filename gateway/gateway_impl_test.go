@@ -0,0 +1,263 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+)
+
+// testServerMessage is a Message received by the testServer.
+type testServerMessage struct {
+	Message
+	// Conn is the number of the connection the Message was received on, starting at 1.
+	Conn int
+	Time time.Time
+}
+
+// testServer is a websocket server which speaks just enough of the gateway protocol to identify, resume & heartbeat.
+type testServer struct {
+	*httptest.Server
+	heartbeatInterval time.Duration
+	// ackHeartbeats is whether OpcodeHeartbeat(s) are answered with an OpcodeHeartbeatACK.
+	ackHeartbeats bool
+	received      chan testServerMessage
+
+	conns   int
+	conn    *websocket.Conn
+	helloAt time.Time
+	mu      sync.Mutex
+}
+
+func newTestServer(t *testing.T, heartbeatInterval time.Duration, ackHeartbeats bool) *testServer {
+	s := &testServer{
+		heartbeatInterval: heartbeatInterval,
+		ackHeartbeats:     ackHeartbeats,
+		received:          make(chan testServerMessage, 100),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// url returns the websocket URL of the testServer.
+func (s *testServer) url() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// write sends the raw message to the last connection.
+func (s *testServer) write(t *testing.T, data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.NoError(t, s.conn.WriteMessage(websocket.TextMessage, []byte(data)))
+}
+
+// receive waits for the next Message with the given Opcode and skips all others.
+func (s *testServer) receive(t *testing.T, op Opcode) testServerMessage {
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case message := <-s.received:
+			if message.Op == op {
+				return message
+			}
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for gateway message", "opcode: %d", op)
+			return testServerMessage{}
+		}
+	}
+}
+
+func (s *testServer) handle(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	s.mu.Lock()
+	s.conns++
+	connNumber := s.conns
+	s.conn = conn
+	s.helloAt = time.Now()
+	err = conn.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"op":10,"d":{"heartbeat_interval":%d}}`, s.heartbeatInterval.Milliseconds())))
+	s.mu.Unlock()
+	if err != nil {
+		return
+	}
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var message Message
+		if err = json.Unmarshal(data, &message); err != nil {
+			continue
+		}
+		s.received <- testServerMessage{Message: message, Conn: connNumber, Time: time.Now()}
+
+		var response string
+		switch message.Op {
+		case OpcodeIdentify:
+			response = `{"op":0,"s":1,"t":"READY","d":{"v":10,"user":{"id":"123","username":"bot"},"guilds":[],"session_id":"session","resume_gateway_url":"` + s.url() + `"}}`
+		case OpcodeResume:
+			response = `{"op":0,"s":2,"t":"RESUMED","d":{}}`
+		case OpcodeHeartbeat:
+			if s.ackHeartbeats {
+				response = `{"op":11}`
+			}
+		}
+		if response == "" {
+			continue
+		}
+		s.mu.Lock()
+		err = conn.WriteMessage(websocket.TextMessage, []byte(response))
+		s.mu.Unlock()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// newTestGateway returns a Gateway connecting to the testServer which sends all its status changes to the returned channel.
+func newTestGateway(t *testing.T, server *testServer, eventHandlerFunc EventHandlerFunc) (Gateway, <-chan EventStatusChange) {
+	statusChanges := make(chan EventStatusChange, 100)
+	if eventHandlerFunc == nil {
+		eventHandlerFunc = func(EventType, int, int, EventData) {}
+	}
+	g := New("MTIz.test.token", eventHandlerFunc, nil,
+		WithURL(server.url()),
+		WithCompress(false),
+		WithStatusChangeHandler(func(_ Gateway, event EventStatusChange) {
+			statusChanges <- event
+		}),
+	)
+	t.Cleanup(func() {
+		g.Close(context.Background())
+	})
+	return g, statusChanges
+}
+
+// receiveStatusChanges waits for the given number of status changes.
+func receiveStatusChanges(t *testing.T, statusChanges <-chan EventStatusChange, n int) []EventStatusChange {
+	events := make([]EventStatusChange, 0, n)
+	timeout := time.After(5 * time.Second)
+	for len(events) < n {
+		select {
+		case event := <-statusChanges:
+			events = append(events, event)
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for status changes", "received: %v", events)
+		}
+	}
+	return events
+}
+
+func statuses(events []EventStatusChange) []Status {
+	statuses := make([]Status, len(events))
+	for i, event := range events {
+		statuses[i] = event.Status
+	}
+	return statuses
+}
+
+func TestGateway_Zombied(t *testing.T) {
+	interval := 200 * time.Millisecond
+	server := newTestServer(t, interval, false)
+
+	zombied := make(chan EventZombied, 1)
+	g, statusChanges := newTestGateway(t, server, func(eventType EventType, _ int, _ int, event EventData) {
+		if eventType == EventTypeZombied {
+			zombied <- event.(EventZombied)
+		}
+	})
+	require.NoError(t, g.Open(context.Background()))
+	server.receive(t, OpcodeIdentify)
+	receiveStatusChanges(t, statusChanges, 5)
+	assert.Equal(t, StatusReady, g.Status())
+
+	// the first heartbeat is jittered, so it is sent within the first interval instead of after it
+	heartbeat := server.receive(t, OpcodeHeartbeat)
+	server.mu.Lock()
+	helloAt := server.helloAt
+	server.mu.Unlock()
+	assert.Less(t, heartbeat.Time.Sub(helloAt), interval+50*time.Millisecond)
+
+	// without an ACK, the connection is considered dead after the next interval
+	select {
+	case event := <-zombied:
+		assert.True(t, event.LastHeartbeatReceived.Before(event.LastHeartbeatSent))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for zombied event")
+	}
+
+	events := receiveStatusChanges(t, statusChanges, 5)
+	assert.Equal(t, []Status{StatusZombied, StatusDisconnected, StatusConnecting, StatusWaitingForHello, StatusResuming}, statuses(events))
+	assert.ErrorIs(t, events[0].Cause, discord.ErrGatewayZombied)
+	assert.ErrorIs(t, events[1].Cause, discord.ErrGatewayZombied)
+	assert.Equal(t, 1, events[2].Attempt)
+
+	// the session is kept, so the new connection resumes it
+	resume := server.receive(t, OpcodeResume)
+	assert.Equal(t, 2, resume.Conn)
+	assert.Equal(t, "session", resume.D.(MessageDataResume).SessionID)
+	assert.Equal(t, 1, resume.D.(MessageDataResume).Seq)
+}
+
+func TestGateway_StatusChanges(t *testing.T) {
+	server := newTestServer(t, time.Minute, true)
+	g, statusChanges := newTestGateway(t, server, nil)
+
+	require.NoError(t, g.Open(context.Background()))
+	events := receiveStatusChanges(t, statusChanges, 5)
+	assert.Equal(t, []Status{StatusConnecting, StatusWaitingForHello, StatusIdentifying, StatusWaitingForReady, StatusReady}, statuses(events))
+	for i, event := range events {
+		if i > 0 {
+			assert.Equal(t, events[i-1].Status, event.OldStatus)
+		}
+		assert.Zero(t, event.Attempt)
+		assert.NoError(t, event.Cause)
+	}
+	assert.Equal(t, StatusUnconnected, events[0].OldStatus)
+
+	// a requested reconnect keeps the session & counts the attempt until the Gateway is ready again
+	server.write(t, `{"op":7}`)
+	events = receiveStatusChanges(t, statusChanges, 5)
+	assert.Equal(t, []Status{StatusDisconnected, StatusConnecting, StatusWaitingForHello, StatusResuming, StatusReady}, statuses(events))
+	assert.ErrorIs(t, events[0].Cause, discord.ErrGatewayReconnect)
+	assert.Zero(t, events[0].Attempt)
+	for _, event := range events[1:] {
+		assert.Equal(t, 1, event.Attempt)
+	}
+	assert.Equal(t, 2, server.receive(t, OpcodeResume).Conn)
+
+	// an invalid session which can't be resumed drops the session, so the new connection identifies again
+	server.write(t, `{"op":9,"d":false}`)
+	events = receiveStatusChanges(t, statusChanges, 6)
+	assert.Equal(t, []Status{StatusDisconnected, StatusConnecting, StatusWaitingForHello, StatusIdentifying, StatusWaitingForReady, StatusReady}, statuses(events))
+	assert.ErrorIs(t, events[0].Cause, discord.ErrGatewayInvalidSession)
+	assert.Zero(t, events[0].Attempt)
+	assert.Equal(t, 1, events[1].Attempt)
+	assert.Equal(t, 3, server.receive(t, OpcodeIdentify).Conn)
+
+	// the attempt is reset once the Gateway is ready & closing it drops the session
+	g.Close(context.Background())
+	events = receiveStatusChanges(t, statusChanges, 1)
+	assert.Equal(t, StatusDisconnected, events[0].Status)
+	assert.NoError(t, events[0].Cause)
+	assert.Zero(t, events[0].Attempt)
+	assert.Nil(t, g.SessionID())
+	assert.Nil(t, g.LastSequenceReceived())
+}
@@ -25,3 +25,14 @@ type RateLimiter interface {
 	// Unlock unlocks the RateLimiter and allows the next message to be sent.
 	Unlock()
 }
+
+// IdentifyRateLimiter limits how many Gateway(s) can identify at the same time, as Discord only allows max_concurrency identifies every 5 seconds.
+// It is only used for identifying and not for resuming. sharding.RateLimiter implements it.
+type IdentifyRateLimiter interface {
+	// WaitBucket waits for the bucket of the given shardID to be available for a new identify.
+	// If the context is canceled, WaitBucket returns immediately without locking the bucket.
+	WaitBucket(ctx context.Context, shardID int) error
+
+	// UnlockBucket unlocks the bucket of the given shardID after the identify was sent.
+	UnlockBucket(shardID int)
+}
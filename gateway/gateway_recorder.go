@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"bufio"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+// RecordDirection is the direction of a RecordedMessage.
+type RecordDirection string
+
+// All RecordDirection(s)
+const (
+	RecordDirectionReceive RecordDirection = "receive"
+	RecordDirectionSend    RecordDirection = "send"
+)
+
+// RecordedMessage is a gateway Message recorded by a Recorder with the time it was received or sent.
+type RecordedMessage struct {
+	Time      time.Time       `json:"time"`
+	Direction RecordDirection `json:"direction"`
+	Op        Opcode          `json:"op"`
+	S         int             `json:"s,omitempty"`
+	T         EventType       `json:"t,omitempty"`
+	D         json.RawMessage `json:"d,omitempty"`
+}
+
+// Recorder records the traffic of a Gateway. Tokens are removed from Identify & Resume commands before recording them.
+// Use NewReplayCreateFunc to replay a recording.
+type Recorder interface {
+	// Record records the given RecordedMessage.
+	Record(message RecordedMessage) error
+}
+
+var _ Recorder = (*recorderImpl)(nil)
+
+// NewRecorder returns a Recorder which writes one RecordedMessage per line as JSON to the given io.Writer, for example an *os.File.
+func NewRecorder(writer io.Writer) Recorder {
+	return &recorderImpl{
+		writer: writer,
+	}
+}
+
+type recorderImpl struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func (r *recorderImpl) Record(message RecordedMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err = r.writer.Write(append(data, '\n'))
+	return err
+}
+
+// ReadRecording reads the RecordedMessage(s) written by a Recorder created by NewRecorder.
+func ReadRecording(reader io.Reader) ([]RecordedMessage, error) {
+	var messages []RecordedMessage
+	scanner := bufio.NewScanner(reader)
+	// guild creates of large guilds don't fit into the default buffer
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var message RecordedMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, scanner.Err()
+}
+
+// redactToken removes the token of Identify & Resume commands.
+func redactToken(data MessageData) MessageData {
+	switch d := data.(type) {
+	case MessageDataIdentify:
+		d.Token = ""
+		return d
+	case MessageDataResume:
+		d.Token = ""
+		return d
+	}
+	return data
+}
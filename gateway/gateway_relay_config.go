@@ -0,0 +1,68 @@
+package gateway
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// DefaultRelayConfig returns a RelayConfig with sensible defaults.
+func DefaultRelayConfig() *RelayConfig {
+	return &RelayConfig{
+		Dialer:         websocket.DefaultDialer,
+		NetDialer:      &net.Dialer{},
+		ReconnectDelay: time.Second,
+	}
+}
+
+// RelayConfig lets you configure the Gateway(s) created by NewRelayCreateFunc.
+type RelayConfig struct {
+	// Dialer is used to connect to ws:// & wss:// relays.
+	Dialer *websocket.Dialer
+	// NetDialer is used to connect to tcp:// relays.
+	NetDialer *net.Dialer
+	// Header is sent when connecting to ws:// & wss:// relays, e.g. to authenticate.
+	Header http.Header
+	// ReconnectDelay is multiplied with the number of failed reconnect attempts.
+	ReconnectDelay time.Duration
+}
+
+// RelayConfigOpt is a type alias for a function that takes a RelayConfig and is used to configure your relay Gateway.
+type RelayConfigOpt func(config *RelayConfig)
+
+// Apply applies the given RelayConfigOpt(s) to the RelayConfig
+func (c *RelayConfig) Apply(opts []RelayConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithRelayDialer sets the websocket.Dialer used to connect to ws:// & wss:// relays.
+func WithRelayDialer(dialer *websocket.Dialer) RelayConfigOpt {
+	return func(config *RelayConfig) {
+		config.Dialer = dialer
+	}
+}
+
+// WithRelayNetDialer sets the net.Dialer used to connect to tcp:// relays.
+func WithRelayNetDialer(netDialer *net.Dialer) RelayConfigOpt {
+	return func(config *RelayConfig) {
+		config.NetDialer = netDialer
+	}
+}
+
+// WithRelayHeader sets the http.Header sent when connecting to ws:// & wss:// relays.
+func WithRelayHeader(header http.Header) RelayConfigOpt {
+	return func(config *RelayConfig) {
+		config.Header = header
+	}
+}
+
+// WithRelayReconnectDelay sets the delay which is multiplied with the number of failed reconnect attempts.
+func WithRelayReconnectDelay(reconnectDelay time.Duration) RelayConfigOpt {
+	return func(config *RelayConfig) {
+		config.ReconnectDelay = reconnectDelay
+	}
+}
@@ -0,0 +1,367 @@
+package gateway
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+	"github.com/gorilla/websocket"
+)
+
+var _ Gateway = (*relayImpl)(nil)
+
+// NewRelayCreateFunc returns a CreateFunc which creates Gateway(s) that receive their events from a relay at the given URL instead of Discord.
+// This lets a single process hold the connections to Discord while any number of processes handle the events.
+//
+// Relays at ws:// & wss:// URLs exchange one gateway Message per websocket text message, relays at tcp:// URLs one gateway Message per line.
+// After connecting, the Gateway sends an Identify command with its shard & intents, or a Resume command with the last sequence received when reconnecting, but never the token.
+// Afterwards, the relay sends the dispatches of the shard and receives the commands sent via Gateway.Send.
+// As the relay holds the actual session, the Gateway is ready as soon as it is connected.
+func NewRelayCreateFunc(url string, opts ...RelayConfigOpt) CreateFunc {
+	relayConfig := DefaultRelayConfig()
+	relayConfig.Apply(opts)
+
+	return func(_ string, eventHandlerFunc EventHandlerFunc, closeHandlerFunc CloseHandlerFunc, opts ...ConfigOpt) Gateway {
+		config := DefaultConfig()
+		config.Apply(opts)
+
+		g := &relayImpl{
+			url:              url,
+			config:           *config,
+			relayConfig:      *relayConfig,
+			eventHandlerFunc: eventHandlerFunc,
+			closeHandlerFunc: closeHandlerFunc,
+		}
+		g.connStatus = newConnStatus(g, &g.config, eventHandlerFunc)
+		g.logPrefix = "relay: "
+		g.closeConn = g.closeConnection
+		g.reconnectDelay = relayConfig.ReconnectDelay
+		return g
+	}
+}
+
+type relayImpl struct {
+	*connStatus
+
+	url              string
+	config           Config
+	relayConfig      RelayConfig
+	eventHandlerFunc EventHandlerFunc
+	closeHandlerFunc CloseHandlerFunc
+
+	conn   relayConn
+	connMu sync.Mutex
+}
+
+func (g *relayImpl) Logger() log.Logger {
+	return g.config.Logger
+}
+
+func (g *relayImpl) ShardID() int {
+	return g.config.ShardID
+}
+
+func (g *relayImpl) ShardCount() int {
+	return g.config.ShardCount
+}
+
+func (g *relayImpl) SessionID() *string {
+	return g.config.SessionID
+}
+
+func (g *relayImpl) LastSequenceReceived() *int {
+	return g.config.LastSequenceReceived
+}
+
+func (g *relayImpl) Intents() Intents {
+	return g.config.Intents
+}
+
+func (g *relayImpl) Open(ctx context.Context) error {
+	g.Logger().Debug(g.formatLogs("opening relay connection"))
+
+	g.connMu.Lock()
+	connected := g.conn != nil
+	g.connMu.Unlock()
+	if connected {
+		return discord.ErrGatewayAlreadyConnected
+	}
+	g.setStatus(StatusConnecting, nil)
+
+	conn, err := g.dial(ctx)
+	if err != nil {
+		g.Logger().Error(g.formatLogsf("error connecting to the relay. url: %s, error: %s", g.url, err))
+		g.setStatus(StatusDisconnected, err)
+		return err
+	}
+
+	g.connMu.Lock()
+	if g.conn != nil {
+		// another Open call connected in the meantime
+		g.connMu.Unlock()
+		_ = conn.CloseWithCode(websocket.CloseNormalClosure, "already connected")
+		return discord.ErrGatewayAlreadyConnected
+	}
+	g.conn = conn
+	g.config.RateLimiter.Reset()
+	g.connMu.Unlock()
+
+	if g.config.LastSequenceReceived == nil {
+		g.setStatus(StatusIdentifying, nil)
+		identify := MessageDataIdentify{
+			Properties: IdentifyCommandDataProperties{
+				OS:      g.config.OS,
+				Browser: g.config.Browser,
+				Device:  g.config.Device,
+			},
+			LargeThreshold: g.config.LargeThreshold,
+			Shard:          &[2]int{g.ShardID(), g.ShardCount()},
+			Intents:        g.config.Intents,
+			Presence:       g.config.Presence,
+		}
+		err = g.Send(ctx, OpcodeIdentify, identify)
+	} else {
+		g.setStatus(StatusResuming, nil)
+		resume := MessageDataResume{
+			Seq: *g.config.LastSequenceReceived,
+		}
+		if g.config.SessionID != nil {
+			resume.SessionID = *g.config.SessionID
+		}
+		err = g.Send(ctx, OpcodeResume, resume)
+	}
+	if err != nil {
+		g.closeWithCause(ctx, websocket.CloseServiceRestart, "failed to identify", err)
+		return err
+	}
+	g.setStatus(StatusReady, nil)
+
+	go g.listen(conn)
+
+	return nil
+}
+
+// dial connects to the relay with the transport matching the scheme of the URL.
+func (g *relayImpl) dial(ctx context.Context) (relayConn, error) {
+	u, err := url.Parse(g.url)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "ws", "wss":
+		conn, rs, err := g.relayConfig.Dialer.DialContext(ctx, g.url, g.relayConfig.Header)
+		if rs != nil && rs.Body != nil {
+			_ = rs.Body.Close()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &websocketRelayConn{conn: conn}, nil
+
+	case "tcp":
+		conn, err := g.relayConfig.NetDialer.DialContext(ctx, "tcp", u.Host)
+		if err != nil {
+			return nil, err
+		}
+		return &tcpRelayConn{conn: conn, reader: bufio.NewReader(conn)}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported relay url scheme: %s", u.Scheme)
+	}
+}
+
+func (g *relayImpl) Close(ctx context.Context) {
+	g.CloseWithCode(ctx, websocket.CloseNormalClosure, "Shutting down")
+}
+
+func (g *relayImpl) CloseWithCode(ctx context.Context, code int, message string) {
+	g.closeWithCause(ctx, code, message, nil)
+}
+
+// closeConnection closes the connection to the relay. It returns whether there was a connection to close.
+func (g *relayImpl) closeConnection(ctx context.Context, code int, message string) bool {
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn == nil {
+		return false
+	}
+	g.config.RateLimiter.Close(ctx)
+	g.Logger().Debug(g.formatLogsf("closing relay connection with code: %d, message: %s", code, message))
+	if err := g.conn.CloseWithCode(code, message); err != nil {
+		g.Logger().Debug(g.formatLogs("error closing relay connection. error: ", err))
+	}
+	g.conn = nil
+	return true
+}
+
+func (g *relayImpl) Send(ctx context.Context, op Opcode, d MessageData) error {
+	data, err := json.Marshal(Message{
+		Op: op,
+		D:  d,
+	})
+	if err != nil {
+		return err
+	}
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if g.conn == nil {
+		return discord.ErrShardNotConnected
+	}
+
+	if err = g.config.RateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	defer g.config.RateLimiter.Unlock()
+	g.Logger().Trace(g.formatLogs("sending gateway command: ", string(data)))
+	return g.conn.WriteMessage(data)
+}
+
+// Latency always returns 0, as the heartbeats are handled by the relay.
+func (g *relayImpl) Latency() time.Duration {
+	return 0
+}
+
+func (g *relayImpl) listen(conn relayConn) {
+	defer g.Logger().Debug(g.formatLogs("exiting listen goroutine..."))
+	for {
+		data, err := conn.ReadMessage()
+		if err != nil {
+			g.connMu.Lock()
+			sameConnection := g.conn == conn
+			g.connMu.Unlock()
+
+			// if sameConnection is false, it means the connection has been closed by the user, and we can just exit
+			if !sameConnection {
+				return
+			}
+
+			g.Logger().Debug(g.formatLogs("failed to read next message from relay. error: ", err))
+			if g.config.AutoReconnect {
+				// keep the last sequence received, so the relay can replay the missed events
+				g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "reconnecting", err)
+				go g.reconnect(context.TODO())
+			} else {
+				g.closeWithCause(context.TODO(), websocket.CloseNormalClosure, "Shutting down", err)
+				if g.closeHandlerFunc != nil {
+					go g.closeHandlerFunc(g, err)
+				}
+			}
+			return
+		}
+
+		var message Message
+		if err = json.Unmarshal(data, &message); err != nil {
+			g.Logger().Error(g.formatLogs("error while parsing relay message. error: ", err))
+			continue
+		}
+
+		switch message.Op {
+		case OpcodeDispatch:
+			g.Logger().Trace(g.formatLogsf("received: OpcodeDispatch %s, data: %s", message.T, string(message.RawD)))
+
+			if message.S != 0 {
+				g.config.LastSequenceReceived = &message.S
+			}
+
+			eventData, ok := message.D.(EventData)
+			if !ok && message.D != nil {
+				g.Logger().Error(g.formatLogsf("invalid event data of type %T received", message.D))
+				continue
+			}
+			if readyEvent, ok := eventData.(EventReady); ok {
+				g.config.SessionID = &readyEvent.SessionID
+			}
+
+			if g.config.EnableRawEvents {
+				g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, EventRaw{
+					EventType: message.T,
+					Payload:   bytes.NewReader(message.RawD),
+				})
+			}
+			g.eventHandlerFunc(message.T, message.S, g.config.ShardID, eventData)
+
+		case OpcodeReconnect:
+			g.Logger().Debug(g.formatLogs("received: OpcodeReconnect"))
+			g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "received reconnect", discord.ErrGatewayReconnect)
+			go g.reconnect(context.TODO())
+			return
+
+		case OpcodeInvalidSession:
+			g.Logger().Debug(g.formatLogs("received: OpcodeInvalidSession"))
+			if canResume, _ := message.D.(MessageDataInvalidSession); !canResume {
+				g.config.LastSequenceReceived = nil
+				g.config.SessionID = nil
+			}
+			g.closeWithCause(context.TODO(), websocket.CloseServiceRestart, "invalid session", discord.ErrGatewayInvalidSession)
+			go g.reconnect(context.TODO())
+			return
+
+		default:
+			g.Logger().Trace(g.formatLogsf("ignoring relay message with opcode %d", message.Op))
+		}
+	}
+}
+
+// relayConn is a connection to a relay which exchanges one gateway Message per message.
+type relayConn interface {
+	ReadMessage() ([]byte, error)
+	WriteMessage(data []byte) error
+	CloseWithCode(code int, message string) error
+}
+
+type websocketRelayConn struct {
+	conn *websocket.Conn
+}
+
+func (c *websocketRelayConn) ReadMessage() ([]byte, error) {
+	_, data, err := c.conn.ReadMessage()
+	return data, err
+}
+
+func (c *websocketRelayConn) WriteMessage(data []byte) error {
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (c *websocketRelayConn) CloseWithCode(code int, message string) error {
+	if err := c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, message)); err != nil && err != websocket.ErrCloseSent {
+		_ = c.conn.Close()
+		return err
+	}
+	return c.conn.Close()
+}
+
+type tcpRelayConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (c *tcpRelayConn) ReadMessage() ([]byte, error) {
+	for {
+		line, err := c.reader.ReadBytes('\n')
+		if err != nil {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			return line, nil
+		}
+	}
+}
+
+func (c *tcpRelayConn) WriteMessage(data []byte) error {
+	_, err := c.conn.Write(append(data, '\n'))
+	return err
+}
+
+func (c *tcpRelayConn) CloseWithCode(_ int, _ string) error {
+	return c.conn.Close()
+}
@@ -0,0 +1,67 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+func TestRelay(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	events := make(chan EventData, 1)
+	eventHandlerFunc := func(eventType EventType, sequenceNumber int, shardID int, event EventData) {
+		if eventType == EventTypeMessageCreate {
+			assert.Equal(t, 5, sequenceNumber)
+			assert.Equal(t, 1, shardID)
+			events <- event
+		}
+	}
+	g := NewRelayCreateFunc("tcp://"+listener.Addr().String())("", eventHandlerFunc, nil, WithShardID(1), WithShardCount(2), WithIntents(IntentGuildMessages))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, g.Open(ctx))
+	assert.Equal(t, StatusReady, g.Status())
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+	defer g.Close(ctx)
+	reader := bufio.NewReader(conn)
+
+	var identify Message
+	line, err := reader.ReadBytes('\n')
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(line, &identify))
+	assert.Equal(t, OpcodeIdentify, identify.Op)
+	assert.Equal(t, &[2]int{1, 2}, identify.D.(MessageDataIdentify).Shard)
+	assert.Equal(t, IntentGuildMessages, identify.D.(MessageDataIdentify).Intents)
+	assert.Empty(t, identify.D.(MessageDataIdentify).Token)
+
+	_, err = conn.Write([]byte(`{"op":0,"s":5,"t":"MESSAGE_CREATE","d":{"id":"1","channel_id":"2","content":"hello"}}` + "\n"))
+	require.NoError(t, err)
+	select {
+	case event := <-events:
+		assert.Equal(t, "hello", event.(EventMessageCreate).Content)
+	case <-ctx.Done():
+		t.Fatal("no event received")
+	}
+	assert.Equal(t, 5, *g.LastSequenceReceived())
+
+	require.NoError(t, g.Send(ctx, OpcodePresenceUpdate, MessageDataPresenceUpdate{Status: "idle"}))
+	var presence Message
+	line, err = reader.ReadBytes('\n')
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(line, &presence))
+	assert.Equal(t, OpcodePresenceUpdate, presence.Op)
+}
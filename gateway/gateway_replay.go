@@ -0,0 +1,237 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/log"
+)
+
+// ReplayGateway is a Gateway which replays a recording of a Recorder instead of connecting to Discord.
+// It lets you test how your bot handles real sequences of events.
+type ReplayGateway interface {
+	Gateway
+
+	// Sent returns all commands sent via Gateway.Send.
+	Sent() []Message
+
+	// Done is closed once all recorded events were dispatched.
+	Done() <-chan struct{}
+}
+
+// DefaultReplayConfig returns a ReplayConfig with sensible defaults.
+func DefaultReplayConfig() *ReplayConfig {
+	return &ReplayConfig{}
+}
+
+// ReplayConfig lets you configure the ReplayGateway(s) created by NewReplayCreateFunc.
+type ReplayConfig struct {
+	// Speed is the factor the recorded timings are replayed with, e.g. 1 for real time or 2 for twice as fast.
+	// If it is 0, all events are dispatched before Gateway.Open returns, which makes tests deterministic.
+	Speed float64
+}
+
+// ReplayConfigOpt is a type alias for a function that takes a ReplayConfig and is used to configure your ReplayGateway.
+type ReplayConfigOpt func(config *ReplayConfig)
+
+// Apply applies the given ReplayConfigOpt(s) to the ReplayConfig
+func (c *ReplayConfig) Apply(opts []ReplayConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithReplaySpeed sets the factor the recorded timings are replayed with.
+func WithReplaySpeed(speed float64) ReplayConfigOpt {
+	return func(config *ReplayConfig) {
+		config.Speed = speed
+	}
+}
+
+var _ ReplayGateway = (*replayImpl)(nil)
+
+// NewReplayCreateFunc returns a CreateFunc which creates ReplayGateway(s) that dispatch the received events of the given recording when opened.
+// Pass it to bot.WithGatewayCreateFunc to replay a recording into a bot.Client:
+//
+//	file, _ := os.Open("testdata/recording.jsonl")
+//	recording, _ := gateway.ReadRecording(file)
+//	client, _ := disgo.New(token, bot.WithGatewayCreateFunc(gateway.NewReplayCreateFunc(recording)))
+//	_ = client.OpenGateway(context.TODO())
+func NewReplayCreateFunc(recording []RecordedMessage, opts ...ReplayConfigOpt) CreateFunc {
+	replayConfig := DefaultReplayConfig()
+	replayConfig.Apply(opts)
+
+	return func(_ string, eventHandlerFunc EventHandlerFunc, _ CloseHandlerFunc, opts ...ConfigOpt) Gateway {
+		config := DefaultConfig()
+		config.Apply(opts)
+
+		g := &replayImpl{
+			recording:        recording,
+			config:           *config,
+			replayConfig:     *replayConfig,
+			eventHandlerFunc: eventHandlerFunc,
+			done:             make(chan struct{}),
+		}
+		// the replay has no connection to close or reconnect, so only the Status is used
+		g.connStatus = newConnStatus(g, &g.config, eventHandlerFunc)
+		return g
+	}
+}
+
+type replayImpl struct {
+	*connStatus
+
+	recording        []RecordedMessage
+	config           Config
+	replayConfig     ReplayConfig
+	eventHandlerFunc EventHandlerFunc
+
+	cancel context.CancelFunc
+	sent   []Message
+	mu     sync.Mutex
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (g *replayImpl) Logger() log.Logger {
+	return g.config.Logger
+}
+
+func (g *replayImpl) ShardID() int {
+	return g.config.ShardID
+}
+
+func (g *replayImpl) ShardCount() int {
+	return g.config.ShardCount
+}
+
+func (g *replayImpl) SessionID() *string {
+	return g.config.SessionID
+}
+
+func (g *replayImpl) LastSequenceReceived() *int {
+	return g.config.LastSequenceReceived
+}
+
+func (g *replayImpl) Intents() Intents {
+	return g.config.Intents
+}
+
+func (g *replayImpl) Open(_ context.Context) error {
+	g.mu.Lock()
+	if g.cancel != nil {
+		g.mu.Unlock()
+		return discord.ErrGatewayAlreadyConnected
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	g.cancel = cancel
+	g.mu.Unlock()
+
+	g.setStatus(StatusReady, nil)
+	if g.replayConfig.Speed == 0 {
+		g.replay(ctx)
+		return nil
+	}
+	go g.replay(ctx)
+	return nil
+}
+
+// replay dispatches the received events of the recording with the recorded timings divided by the configured speed.
+func (g *replayImpl) replay(ctx context.Context) {
+	defer g.doneOnce.Do(func() {
+		close(g.done)
+	})
+
+	var last time.Time
+	for _, message := range g.recording {
+		if message.Direction != RecordDirectionReceive || message.Op != OpcodeDispatch {
+			continue
+		}
+		if g.replayConfig.Speed > 0 && !last.IsZero() {
+			timer := time.NewTimer(time.Duration(float64(message.Time.Sub(last)) / g.replayConfig.Speed))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+		last = message.Time
+		if ctx.Err() != nil {
+			return
+		}
+		g.dispatch(message)
+	}
+}
+
+func (g *replayImpl) dispatch(message RecordedMessage) {
+	data, err := UnmarshalEventData(message.D, message.T)
+	if err != nil {
+		g.Logger().Errorf("failed to unmarshal recorded %s event: %s", message.T, err)
+		return
+	}
+	sequenceNumber := message.S
+	g.config.LastSequenceReceived = &sequenceNumber
+	if readyEvent, ok := data.(EventReady); ok {
+		g.config.SessionID = &readyEvent.SessionID
+	}
+
+	if g.config.EnableRawEvents {
+		g.eventHandlerFunc(EventTypeRaw, message.S, g.config.ShardID, EventRaw{
+			EventType: message.T,
+			Payload:   bytes.NewReader(message.D),
+		})
+	}
+	g.eventHandlerFunc(message.T, message.S, g.config.ShardID, data)
+}
+
+func (g *replayImpl) Close(ctx context.Context) {
+	g.CloseWithCode(ctx, 0, "")
+}
+
+func (g *replayImpl) CloseWithCode(_ context.Context, _ int, _ string) {
+	g.mu.Lock()
+	cancel := g.cancel
+	g.cancel = nil
+	g.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		g.setStatus(StatusDisconnected, nil)
+	}
+}
+
+func (g *replayImpl) Send(_ context.Context, op Opcode, d MessageData) error {
+	if g.Status() != StatusReady {
+		return discord.ErrShardNotConnected
+	}
+	rawD, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.sent = append(g.sent, Message{Op: op, D: d, RawD: rawD})
+	return nil
+}
+
+func (g *replayImpl) Sent() []Message {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	sent := make([]Message, len(g.sent))
+	copy(sent, g.sent)
+	return sent
+}
+
+func (g *replayImpl) Done() <-chan struct{} {
+	return g.done
+}
+
+// Latency always returns 0, as no heartbeats are sent.
+func (g *replayImpl) Latency() time.Duration {
+	return 0
+}
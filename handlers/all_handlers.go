@@ -34,6 +34,8 @@ var allEventHandlers = []bot.GatewayEventHandler{
 	bot.NewGatewayEventHandler(gateway.EventTypeRaw, gatewayHandlerRaw),
 	bot.NewGatewayEventHandler(gateway.EventTypeReady, gatewayHandlerReady),
 	bot.NewGatewayEventHandler(gateway.EventTypeResumed, gatewayHandlerResumed),
+	bot.NewGatewayEventHandler(gateway.EventTypeZombied, gatewayHandlerZombied),
+	bot.NewGatewayEventHandler(gateway.EventTypeStatusChange, gatewayHandlerStatusChange),
 
 	bot.NewGatewayEventHandler(gateway.EventTypeApplicationCommandPermissionsUpdate, gatewayHandlerApplicationCommandPermissionsUpdate),
 
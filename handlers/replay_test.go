@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cache"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// newReplayClient returns a bot.Client with the default handlers whose gateway.Gateway replays the given recording.
+func newReplayClient(t *testing.T, recordingPath string, opts ...bot.ConfigOpt) bot.Client {
+	file, err := os.Open(recordingPath)
+	require.NoError(t, err)
+	defer file.Close()
+	recording, err := gateway.ReadRecording(file)
+	require.NoError(t, err)
+
+	config := bot.DefaultConfig(GetGatewayHandlers(), GetHTTPServerHandler())
+	config.Apply(append([]bot.ConfigOpt{bot.WithGatewayCreateFunc(gateway.NewReplayCreateFunc(recording))}, opts...))
+	// the token only needs to contain the id of the bot
+	client, err := bot.BuildClient("MTIz.replay.token", *config, DefaultGatewayEventHandlerFunc, DefaultHTTPServerEventHandlerFunc, "linux", "disgo", "", "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestReplay_ChannelUpdate(t *testing.T) {
+	var updates []*events.GuildChannelUpdate
+	client := newReplayClient(t, "testdata/channel_update.jsonl", bot.WithCacheConfigOpts(cache.WithCacheFlags(cache.FlagsAll)), bot.WithEventListenerFunc(func(e *events.GuildChannelUpdate) {
+		updates = append(updates, e)
+	}))
+	require.NoError(t, client.OpenGateway(context.Background()))
+
+	guild, ok := client.Caches().Guilds().Get(snowflake.ID(1000))
+	assert.True(t, ok)
+	assert.Equal(t, "Test Guild", guild.Name)
+	assert.False(t, client.Caches().Guilds().IsUnready(0, guild.ID))
+
+	channel, ok := client.Caches().Channels().GetGuildChannel(snowflake.ID(2000))
+	assert.True(t, ok)
+	assert.Equal(t, "renamed", channel.Name())
+
+	require.Len(t, updates, 1)
+	assert.Equal(t, "general", updates[0].OldChannel.Name())
+	assert.Equal(t, "renamed", updates[0].Channel.Name())
+	assert.Equal(t, 3, updates[0].SequenceNumber())
+	assert.Equal(t, 3, *client.Gateway().LastSequenceReceived())
+}
@@ -26,6 +26,20 @@ func gatewayHandlerReady(client bot.Client, sequenceNumber int, shardID int, eve
 	})
 }
 
+func gatewayHandlerStatusChange(client bot.Client, sequenceNumber int, shardID int, event gateway.EventStatusChange) {
+	client.EventManager().DispatchEvent(&events.GatewayStatusChange{
+		GenericEvent:      events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventStatusChange: event,
+	})
+}
+
+func gatewayHandlerZombied(client bot.Client, sequenceNumber int, shardID int, event gateway.EventZombied) {
+	client.EventManager().DispatchEvent(&events.Zombied{
+		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
+		EventZombied: event,
+	})
+}
+
 func gatewayHandlerResumed(client bot.Client, sequenceNumber int, shardID int, _ gateway.EventData) {
 	client.EventManager().DispatchEvent(&events.Resumed{
 		GenericEvent: events.NewGenericEvent(client, sequenceNumber, shardID),
@@ -0,0 +1,88 @@
+package httpserver
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/json"
+)
+
+// Router is a http.Handler which receives interactions of multiple applications at one URL.
+// Each interaction is passed to the Server of its application, which verifies it with its own public key.
+// To route by path instead, mount the Server.Handler of each application at its own path.
+type Router interface {
+	http.Handler
+
+	// AddServer adds the Server of the given application.
+	AddServer(applicationID snowflake.ID, server Server)
+
+	// RemoveServer removes the Server of the given application.
+	RemoveServer(applicationID snowflake.ID)
+
+	// Server returns the Server of the given application or nil if none was added.
+	Server(applicationID snowflake.ID) Server
+}
+
+var _ Router = (*routerImpl)(nil)
+
+// NewRouter creates a new Router with the given Server(s) by application id.
+func NewRouter(servers map[snowflake.ID]Server) Router {
+	r := &routerImpl{servers: map[snowflake.ID]Server{}}
+	for applicationID, server := range servers {
+		r.servers[applicationID] = server
+	}
+	return r
+}
+
+type routerImpl struct {
+	servers map[snowflake.ID]Server
+	mu      sync.RWMutex
+}
+
+func (r *routerImpl) AddServer(applicationID snowflake.ID, server Server) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.servers[applicationID] = server
+}
+
+func (r *routerImpl) RemoveServer(applicationID snowflake.ID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.servers, applicationID)
+}
+
+func (r *routerImpl) Server(applicationID snowflake.ID) Server {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.servers[applicationID]
+}
+
+func (r *routerImpl) ServeHTTP(w http.ResponseWriter, rq *http.Request) {
+	data, err := io.ReadAll(rq.Body)
+	_ = rq.Body.Close()
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	// the payload is not verified yet, but it is only used to pick the public key to verify it with
+	var v struct {
+		ApplicationID snowflake.ID `json:"application_id"`
+	}
+	if err = json.Unmarshal(data, &v); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	server := r.Server(v.ApplicationID)
+	if server == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	rq.Body = io.NopCloser(bytes.NewReader(data))
+	server.Handler().ServeHTTP(w, rq)
+}
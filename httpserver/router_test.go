@@ -0,0 +1,63 @@
+package httpserver
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+)
+
+func newTestServer(t *testing.T, handled *string, name string) (Server, ed25519.PrivateKey) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	server := New(hex.EncodeToString(publicKey), func(respond RespondFunc, _ gateway.EventInteractionCreate) {
+		*handled = name
+		_ = respond(discord.InteractionResponse{Type: discord.InteractionResponseTypePong})
+	}, WithLogger(log.Default()))
+	return server, privateKey
+}
+
+func newSignedRequest(privateKey ed25519.PrivateKey, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(body)))
+	r.Header.Set("X-Signature-Timestamp", "0")
+	r.Header.Set("X-Signature-Ed25519", hex.EncodeToString(ed25519.Sign(privateKey, []byte("0"+body))))
+	return r
+}
+
+func TestRouter(t *testing.T) {
+	var handled string
+	server1, key1 := newTestServer(t, &handled, "1")
+	server2, key2 := newTestServer(t, &handled, "2")
+	router := NewRouter(map[snowflake.ID]Server{1: server1, 2: server2})
+
+	tests := []struct {
+		name    string
+		key     ed25519.PrivateKey
+		body    string
+		status  int
+		handled string
+	}{
+		{name: "first", key: key1, body: `{"type":1,"application_id":"1"}`, status: http.StatusOK, handled: "1"},
+		{name: "second", key: key2, body: `{"type":1,"application_id":"2"}`, status: http.StatusOK, handled: "2"},
+		{name: "wrong key", key: key1, body: `{"type":1,"application_id":"2"}`, status: http.StatusUnauthorized},
+		{name: "unknown application", key: key1, body: `{"type":1,"application_id":"3"}`, status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled = ""
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newSignedRequest(tt.key, tt.body))
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.handled, handled)
+		})
+	}
+}
@@ -28,6 +28,10 @@ type Server interface {
 	// PublicKey returns the public key used by the Server
 	PublicKey() PublicKey
 
+	// Handler returns the http.Handler which verifies & handles interactions.
+	// Mount it on your own router instead of calling Start to share your http.Server and its graceful shutdown.
+	Handler() http.Handler
+
 	// Start starts the Server
 	Start()
 
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"io"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -53,8 +54,12 @@ func (s *serverImpl) Handle(respondFunc RespondFunc, event gateway.EventInteract
 	s.eventHandlerFunc(respondFunc, event)
 }
 
+func (s *serverImpl) Handler() http.Handler {
+	return &WebhookInteractionHandler{server: s}
+}
+
 func (s *serverImpl) Start() {
-	s.config.ServeMux.Handle(s.config.URL, &WebhookInteractionHandler{server: s})
+	s.config.ServeMux.Handle(s.config.URL, s.Handler())
 	s.config.HTTPServer.Addr = s.config.Address
 	s.config.HTTPServer.Handler = s.config.ServeMux
 
@@ -89,24 +94,103 @@ const (
 )
 
 func (h *WebhookInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if ok := VerifyRequest(r, h.server.PublicKey()); !ok {
-		w.WriteHeader(http.StatusUnauthorized)
-		data, _ := io.ReadAll(r.Body)
-		h.server.Logger().Trace("received http interaction with invalid signature. body: ", string(data))
-		return
-	}
-
 	defer func() {
 		_ = r.Body.Close()
 	}()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	handle(r.Context(), h.server, RawRequest{Headers: r.Header, Body: body}, func(rs RawResponse, stream *discord.MultipartStream) error {
+		for key, values := range rs.Headers {
+			w.Header()[key] = values
+		}
+		// multipart bodies are streamed to the client instead of buffering them first
+		if stream != nil {
+			if stream.ContentLength >= 0 {
+				w.Header().Set("Content-Length", strconv.FormatInt(stream.ContentLength, 10))
+			}
+			w.WriteHeader(rs.StatusCode)
+			_, err := stream.WriteTo(w)
+			return err
+		}
+		w.WriteHeader(rs.StatusCode)
+		_, err := w.Write(rs.Body)
+		return err
+	})
+}
+
+// RawRequest is a request to the interactions endpoint as received by any platform.
+type RawRequest struct {
+	Headers http.Header
+	Body    []byte
+}
+
+// RawResponse is the response to a RawRequest which needs to be returned by the platform.
+type RawResponse struct {
+	StatusCode int
+	Headers    http.Header
+	Body       []byte
+}
+
+func rawError(statusCode int, message string) RawResponse {
+	rs := RawResponse{
+		StatusCode: statusCode,
+		Headers: http.Header{
+			"Content-Type":           {"text/plain; charset=utf-8"},
+			"X-Content-Type-Options": {"nosniff"},
+		},
+	}
+	if message != "" {
+		rs.Body = []byte(message + "\n")
+	}
+	return rs
+}
+
+// HandleRaw verifies & handles the given RawRequest with the Server and returns the RawResponse.
+// It does not need a listener, so it can be wrapped for the handler signature of any serverless platform.
+// The interaction times out after 3s or when the context.Context is done.
+func HandleRaw(ctx context.Context, server Server, rq RawRequest) RawResponse {
+	var rs RawResponse
+	handle(ctx, server, rq, func(response RawResponse, stream *discord.MultipartStream) error {
+		rs = response
+		if stream == nil {
+			return nil
+		}
+		buff := &bytes.Buffer{}
+		if _, err := stream.WriteTo(buff); err != nil {
+			rs = rawError(http.StatusInternalServerError, "internal server error")
+			return err
+		}
+		rs.Body = buff.Bytes()
+		return nil
+	})
+	return rs
+}
 
-	buff := new(bytes.Buffer)
-	rqData, _ := io.ReadAll(io.TeeReader(r.Body, buff))
-	h.server.Logger().Trace("received http interaction. body: ", string(rqData))
+// handle verifies & handles the given RawRequest and writes the response with the given func.
+// Multipart bodies are passed as *discord.MultipartStream and the error of writing them is returned to whoever responded to the interaction.
+func handle(ctx context.Context, server Server, rq RawRequest, write func(rs RawResponse, stream *discord.MultipartStream) error) {
+	r, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(rq.Body))
+	if err != nil {
+		_ = write(rawError(http.StatusBadRequest, "bad request"), nil)
+		return
+	}
+	r.Header = rq.Headers
+	if ok := VerifyRequest(r, server.PublicKey()); !ok {
+		server.Logger().Trace("received http interaction with invalid signature. body: ", string(rq.Body))
+		_ = write(RawResponse{StatusCode: http.StatusUnauthorized}, nil)
+		return
+	}
+
+	server.Logger().Trace("received http interaction. body: ", string(rq.Body))
 
 	var v gateway.EventInteractionCreate
-	if err := json.NewDecoder(buff).Decode(&v); err != nil {
-		h.server.Logger().Error("error while decoding interaction: ", err)
+	if err = json.Unmarshal(rq.Body, &v); err != nil {
+		server.Logger().Error("error while decoding interaction: ", err)
+		_ = write(rawError(http.StatusBadRequest, "bad request"), nil)
 		return
 	}
 
@@ -123,7 +207,7 @@ func (h *WebhookInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	)
 
 	// send interaction to our handler
-	go h.server.Handle(func(response discord.InteractionResponse) error {
+	go server.Handle(func(response discord.InteractionResponse) error {
 		mu.Lock()
 		defer mu.Unlock()
 
@@ -141,10 +225,7 @@ func (h *WebhookInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return <-errorChannel
 	}, v)
 
-	var (
-		body any
-		err  error
-	)
+	var body any
 
 	// wait for the interaction to be responded to or to time out after 3s
 	timer := time.NewTimer(time.Second * 3)
@@ -152,36 +233,58 @@ func (h *WebhookInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	select {
 	case response := <-responseChannel:
 		if body, err = response.ToBody(); err != nil {
-			http.Error(w, "internal server error", http.StatusInternalServerError)
+			_ = write(rawError(http.StatusInternalServerError, "internal server error"), nil)
 			errorChannel <- err
 			return
 		}
 
 	case <-timer.C:
-		mu.Lock()
-		defer mu.Unlock()
-		status = replyStatusTimedOut
+		_ = write(timeout(server, &mu, &status), nil)
+		return
 
-		h.server.Logger().Debug("interaction timed out")
-		http.Error(w, "interaction timed out", http.StatusRequestTimeout)
+	case <-ctx.Done():
+		_ = write(timeout(server, &mu, &status), nil)
 		return
 	}
 
-	rsBody := &bytes.Buffer{}
-	multiWriter := io.MultiWriter(w, rsBody)
+	rs := RawResponse{
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{},
+	}
+	var stream *discord.MultipartStream
+	switch b := body.(type) {
+	case *discord.MultipartStream:
+		rs.Headers.Set("Content-Type", b.ContentType)
+		stream = b
+
+	case *discord.MultipartBuffer:
+		rs.Headers.Set("Content-Type", b.ContentType)
+		rs.Body = b.Buffer.Bytes()
+
+	default:
+		rs.Headers.Set("Content-Type", "application/json")
+		if rs.Body, err = json.Marshal(body); err != nil {
+			_ = write(rawError(http.StatusInternalServerError, "internal server error"), nil)
+			errorChannel <- err
+			return
+		}
+	}
 
-	if multiPart, ok := body.(*discord.MultipartBuffer); ok {
-		w.Header().Set("Content-Type", multiPart.ContentType)
-		_, err = io.Copy(multiWriter, multiPart.Buffer)
+	if stream != nil {
+		server.Logger().Trace("response to http interaction. body: streamed multipart body")
 	} else {
-		w.Header().Set("Content-Type", "application/json")
-		err = json.NewEncoder(multiWriter).Encode(body)
+		server.Logger().Trace("response to http interaction. body: ", string(rs.Body))
 	}
-	if err != nil {
+	if err = write(rs, stream); err != nil {
 		errorChannel <- err
-		return
 	}
+}
+
+func timeout(server Server, mu *sync.Mutex, status *replyStatus) RawResponse {
+	mu.Lock()
+	defer mu.Unlock()
+	*status = replyStatusTimedOut
 
-	rsData, _ := io.ReadAll(rsBody)
-	h.server.Logger().Trace("response to http interaction. body: ", string(rsData))
+	server.Logger().Debug("interaction timed out")
+	return rawError(http.StatusRequestTimeout, "interaction timed out")
 }
@@ -0,0 +1,26 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleRaw(t *testing.T) {
+	var handled string
+	server, key := newTestServer(t, &handled, "server")
+	_, otherKey := newTestServer(t, &handled, "other")
+
+	body := `{"type":1,"application_id":"1"}`
+	rq := newSignedRequest(key, body)
+	rs := HandleRaw(context.Background(), server, RawRequest{Headers: rq.Header, Body: []byte(body)})
+	assert.Equal(t, http.StatusOK, rs.StatusCode)
+	assert.Equal(t, "application/json", rs.Headers.Get("Content-Type"))
+	assert.JSONEq(t, `{"type":1}`, string(rs.Body))
+
+	rq = newSignedRequest(otherKey, body)
+	rs = HandleRaw(context.Background(), server, RawRequest{Headers: rq.Header, Body: []byte(body)})
+	assert.Equal(t, http.StatusUnauthorized, rs.StatusCode)
+}
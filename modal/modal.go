@@ -0,0 +1,259 @@
+// Package modal provides a helper to open a discord.ModalCreate and wait for its submission.
+package modal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+	"github.com/disgoorg/disgo/rest"
+)
+
+// ErrNoFields is returned by Open when the Modal has no Field(s).
+var ErrNoFields = errors.New("modal has no fields")
+
+// Opener is implemented by all interaction events which can be responded to with a modal.
+// This includes *events.ApplicationCommandInteractionCreate and *events.ComponentInteractionCreate.
+type Opener interface {
+	Client() bot.Client
+	User() discord.User
+	CreateModal(modalCreate discord.ModalCreate, opts ...rest.RequestOpt) error
+}
+
+// Modal describes a modal with its Field(s).
+type Modal struct {
+	// CustomID is the custom id of the modal. If empty, a random one is generated.
+	CustomID discord.CustomID
+
+	// Title is the title of the modal.
+	Title string
+
+	// Fields are the text inputs of the modal. Discord allows up to 5.
+	Fields []Field
+
+	// InvalidMessage builds the message which is sent to the user when the submission fails validation.
+	// If nil, an ephemeral message listing all errors is sent.
+	InvalidMessage func(err *ValidationError) discord.MessageCreate
+}
+
+// New returns a new Modal with the given title & Field(s).
+func New(title string, fields ...Field) Modal {
+	return Modal{
+		Title:  title,
+		Fields: fields,
+	}
+}
+
+// ModalCreate returns the discord.ModalCreate for the Modal.
+func (m Modal) ModalCreate() discord.ModalCreate {
+	builder := discord.NewModalCreateBuilder().
+		SetCustomID(m.CustomID).
+		SetTitle(m.Title)
+	for _, field := range m.Fields {
+		builder.AddActionRow(field.TextInput())
+	}
+	return builder.Build()
+}
+
+// Validate validates the given Values against the Field(s) of the Modal.
+// It returns nil if all Field(s) are valid.
+func (m Modal) Validate(values Values) *ValidationError {
+	fieldErrors := map[discord.CustomID]error{}
+	for _, field := range m.Fields {
+		if err := field.validate(values[field.CustomID]); err != nil {
+			fieldErrors[field.CustomID] = err
+		}
+	}
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return &ValidationError{Modal: m, Errors: fieldErrors}
+}
+
+// NewShortField returns a new single line Field.
+func NewShortField(customID discord.CustomID, label string) Field {
+	return Field{
+		CustomID: customID,
+		Label:    label,
+		Style:    discord.TextInputStyleShort,
+	}
+}
+
+// NewParagraphField returns a new multi line Field.
+func NewParagraphField(customID discord.CustomID, label string) Field {
+	return Field{
+		CustomID: customID,
+		Label:    label,
+		Style:    discord.TextInputStyleParagraph,
+	}
+}
+
+// Field is a single text input of a Modal.
+type Field struct {
+	CustomID    discord.CustomID
+	Label       string
+	Style       discord.TextInputStyle
+	Placeholder string
+	Value       string
+	MinLength   int
+	MaxLength   int
+	Required    bool
+
+	// Validate is called with the submitted value after the length checks passed.
+	Validate func(value string) error
+}
+
+// WithPlaceholder returns a new Field with the provided placeholder
+func (f Field) WithPlaceholder(placeholder string) Field {
+	f.Placeholder = placeholder
+	return f
+}
+
+// WithValue returns a new Field with the provided pre-filled value
+func (f Field) WithValue(value string) Field {
+	f.Value = value
+	return f
+}
+
+// WithMinLength returns a new Field with the provided minLength
+func (f Field) WithMinLength(minLength int) Field {
+	f.MinLength = minLength
+	return f
+}
+
+// WithMaxLength returns a new Field with the provided maxLength
+func (f Field) WithMaxLength(maxLength int) Field {
+	f.MaxLength = maxLength
+	return f
+}
+
+// WithRequired returns a new Field with the provided required
+func (f Field) WithRequired(required bool) Field {
+	f.Required = required
+	return f
+}
+
+// WithValidate returns a new Field with the provided validate func
+func (f Field) WithValidate(validate func(value string) error) Field {
+	f.Validate = validate
+	return f
+}
+
+// TextInput returns the discord.TextInputComponent for the Field.
+func (f Field) TextInput() discord.TextInputComponent {
+	textInput := discord.NewTextInput(f.CustomID, f.Style, f.Label).
+		WithPlaceholder(f.Placeholder).
+		WithValue(f.Value).
+		WithMaxLength(f.MaxLength).
+		WithRequired(f.Required)
+	if f.MinLength > 0 {
+		textInput = textInput.WithMinLength(f.MinLength)
+	}
+	return textInput
+}
+
+func (f Field) validate(value string) error {
+	length := utf8.RuneCountInString(value)
+	if length == 0 {
+		if f.Required {
+			return errors.New("is required")
+		}
+		return nil
+	}
+	if f.MinLength > 0 && length < f.MinLength {
+		return fmt.Errorf("must be at least %d characters long", f.MinLength)
+	}
+	if f.MaxLength > 0 && length > f.MaxLength {
+		return fmt.Errorf("must be at most %d characters long", f.MaxLength)
+	}
+	if f.Validate != nil {
+		return f.Validate(value)
+	}
+	return nil
+}
+
+// ValidationError is returned by Open when the submitted Values did not pass the validation of the Modal.
+type ValidationError struct {
+	Modal  Modal
+	Errors map[discord.CustomID]error
+}
+
+// Error returns all field errors formatted as string.
+func (e *ValidationError) Error() string {
+	return "invalid modal submission: " + strings.Join(e.lines(), ", ")
+}
+
+func (e *ValidationError) lines() []string {
+	lines := make([]string, 0, len(e.Errors))
+	for _, field := range e.Modal.Fields {
+		if err, ok := e.Errors[field.CustomID]; ok {
+			lines = append(lines, fmt.Sprintf("%s %s", field.Label, err))
+		}
+	}
+	return lines
+}
+
+func (e *ValidationError) message() discord.MessageCreate {
+	if e.Modal.InvalidMessage != nil {
+		return e.Modal.InvalidMessage(e)
+	}
+	return discord.NewMessageCreateBuilder().
+		SetContent("Your submission is invalid:\n• " + strings.Join(e.lines(), "\n• ")).
+		SetEphemeral(true).
+		Build()
+}
+
+// Submission is the validated result of a submitted Modal.
+type Submission struct {
+	// Event is the submit event. It still needs to be responded to.
+	Event *events.ModalSubmitInteractionCreate
+
+	// Values are the submitted text inputs by their custom id.
+	Values Values
+}
+
+// Open responds to the event with the Modal and waits for the user to submit it or the context to be done.
+// If the submission does not pass validation, the user is told so with Modal.InvalidMessage and a *ValidationError is returned.
+// Open blocks and therefore needs to be called in its own goroutine when used from an event listener without async events enabled.
+func Open(ctx context.Context, event Opener, modal Modal, opts ...rest.RequestOpt) (*Submission, error) {
+	if len(modal.Fields) == 0 {
+		return nil, ErrNoFields
+	}
+	if modal.CustomID == "" {
+		modal.CustomID = discord.CustomID("modal:" + insecurerandstr.RandStr(32))
+	}
+	userID := event.User().ID
+
+	ch, cancel := bot.NewEventCollector(event.Client(), func(e *events.ModalSubmitInteractionCreate) bool {
+		return e.Data.CustomID == modal.CustomID && e.User().ID == userID
+	})
+	defer cancel()
+
+	if err := event.CreateModal(modal.ModalCreate(), opts...); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	case e := <-ch:
+		values := make(Values, len(e.Data.Components))
+		for customID := range e.Data.Components {
+			values[customID] = e.Data.Text(customID)
+		}
+		if vErr := modal.Validate(values); vErr != nil {
+			if err := e.CreateMessage(vErr.message()); err != nil {
+				e.Client().Logger().Error("failed to report invalid modal submission: ", err)
+			}
+			return nil, vErr
+		}
+		return &Submission{Event: e, Values: values}, nil
+	}
+}
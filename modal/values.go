@@ -0,0 +1,150 @@
+package modal
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// ErrInvalidTarget is returned by Values.Unmarshal when the target is not a pointer to a struct.
+var ErrInvalidTarget = errors.New("target must be a non-nil pointer to a struct")
+
+// Values holds the submitted text input values by their custom id.
+type Values map[discord.CustomID]string
+
+// String returns the value for the given custom id or an empty string.
+func (v Values) String(customID discord.CustomID) string {
+	return v[customID]
+}
+
+// OptString returns the value for the given custom id and whether it is not empty.
+func (v Values) OptString(customID discord.CustomID) (string, bool) {
+	value, ok := v[customID]
+	return value, ok && value != ""
+}
+
+// Int parses the value for the given custom id as int.
+func (v Values) Int(customID discord.CustomID) (int, error) {
+	return strconv.Atoi(v[customID])
+}
+
+// Float parses the value for the given custom id as float64.
+func (v Values) Float(customID discord.CustomID) (float64, error) {
+	return strconv.ParseFloat(v[customID], 64)
+}
+
+// Bool parses the value for the given custom id as bool.
+func (v Values) Bool(customID discord.CustomID) (bool, error) {
+	return strconv.ParseBool(v[customID])
+}
+
+// Snowflake parses the value for the given custom id as snowflake.ID.
+func (v Values) Snowflake(customID discord.CustomID) (snowflake.ID, error) {
+	return snowflake.Parse(v[customID])
+}
+
+// Duration parses the value for the given custom id as time.Duration.
+func (v Values) Duration(customID discord.CustomID) (time.Duration, error) {
+	return time.ParseDuration(v[customID])
+}
+
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	snowflakeType = reflect.TypeOf(snowflake.ID(0))
+)
+
+// Unmarshal fills the exported fields of the struct pointed to by target from the Values.
+// The custom id of a field is taken from its `modal` struct tag and defaults to the field name. Fields tagged with `modal:"-"` are skipped.
+// Supported field kinds are string, bool, ints, uints & floats as well as time.Duration & snowflake.ID. Empty values are left untouched.
+func (v Values) Unmarshal(target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrInvalidTarget
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		structField := rt.Field(i)
+		if !structField.IsExported() {
+			continue
+		}
+		customID := structField.Name
+		if tag, ok := structField.Tag.Lookup("modal"); ok {
+			if tag == "-" {
+				continue
+			}
+			customID = tag
+		}
+		value, ok := v.OptString(discord.CustomID(customID))
+		if !ok {
+			continue
+		}
+		if err := setValue(rv.Field(i), value); err != nil {
+			return fmt.Errorf("failed to unmarshal modal value '%s' into field %s: %w", customID, structField.Name, err)
+		}
+	}
+	return nil
+}
+
+func setValue(field reflect.Value, value string) error {
+	switch field.Type() {
+	case durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+		return nil
+
+	case snowflakeType:
+		id, err := snowflake.Parse(value)
+		if err != nil {
+			return err
+		}
+		field.SetUint(uint64(id))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package modal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValues_Unmarshal(t *testing.T) {
+	type report struct {
+		Title    string        `modal:"title"`
+		Severity int           `modal:"severity"`
+		Timeout  time.Duration `modal:"timeout"`
+		UserID   snowflake.ID  `modal:"user_id"`
+		Body     string
+		Skipped  string `modal:"-"`
+	}
+
+	values := Values{
+		"title":    "broken",
+		"severity": "3",
+		"timeout":  "1m30s",
+		"user_id":  "170939974227591168",
+		"Body":     "it does not work",
+		"-":        "nope",
+	}
+
+	var r report
+	assert.NoError(t, values.Unmarshal(&r))
+	assert.Equal(t, report{
+		Title:    "broken",
+		Severity: 3,
+		Timeout:  90 * time.Second,
+		UserID:   170939974227591168,
+		Body:     "it does not work",
+	}, r)
+
+	assert.Error(t, Values{"severity": "high"}.Unmarshal(&r))
+	assert.ErrorIs(t, values.Unmarshal(r), ErrInvalidTarget)
+}
+
+func TestModal_Validate(t *testing.T) {
+	m := New("Report",
+		NewShortField("title", "Title").WithRequired(true).WithMinLength(3),
+		NewParagraphField("body", "Body").WithMaxLength(5),
+	)
+
+	assert.Nil(t, m.Validate(Values{"title": "abc"}))
+
+	err := m.Validate(Values{"title": "", "body": "too long"})
+	if assert.NotNil(t, err) {
+		assert.Len(t, err.Errors, 2)
+		assert.Equal(t, []string{"Title is required", "Body must be at most 5 characters long"}, err.lines())
+	}
+
+	err = m.Validate(Values{"title": "ab"})
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Errors, discord.CustomID("title"))
+	}
+}
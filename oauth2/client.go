@@ -1,6 +1,7 @@
 package oauth2
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -11,10 +12,16 @@ import (
 )
 
 var (
-	// ErrStateNotFound is returned when the state is not found in the SessionController.
+	// ErrStateNotFound is returned when the state is not found in the StateController.
 	ErrStateNotFound = errors.New("state could not be found")
 
-	// ErrAccessTokenExpired is returned when the access token has expired.
+	// ErrSessionNotFound is returned when the Session is not found in the SessionController.
+	ErrSessionNotFound = errors.New("session could not be found")
+
+	// ErrNoRefreshToken is returned when a Session which was not started with the client credentials grant has no refresh token to refresh it with.
+	ErrNoRefreshToken = errors.New("session has no refresh token")
+
+	// ErrAccessTokenExpired is returned when the access token has expired and WithAutoRefresh is not enabled.
 	ErrAccessTokenExpired = errors.New("access token expired. refresh the session")
 
 	// ErrMissingOAuth2Scope is returned when a specific OAuth2 scope is missing.
@@ -38,16 +45,22 @@ type Client interface {
 	StateController() StateController
 
 	// GenerateAuthorizationURL generates an authorization URL with the given redirect URI, permissions, guildID, disableGuildSelect & scopes. State is automatically generated
-	GenerateAuthorizationURL(redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) string
+	GenerateAuthorizationURL(ctx context.Context, redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, error)
 	// GenerateAuthorizationURLState generates an authorization URL with the given redirect URI, permissions, guildID, disableGuildSelect & scopes. State is automatically generated & returned
-	GenerateAuthorizationURLState(redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, string)
+	GenerateAuthorizationURLState(ctx context.Context, redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, string, error)
 
-	// StartSession starts a new Session with the given authorization code & state
+	// StartSession starts a new Session with the given authorization code & state.
+	// If the discord.OAuth2ScopeBot or discord.OAuth2ScopeWebhookIncoming was authorized, Session.Guild & Session.Webhook return the guild the bot joined & the created webhook
 	StartSession(code string, state string, identifier string, opts ...rest.RequestOpt) (Session, error)
-	// RefreshSession refreshes the given Session with the refresh token
+	// StartClientCredentialsSession starts a new Session of the owner of the application with the given scopes. This is useful to test your application without going through the authorization flow
+	StartClientCredentialsSession(identifier string, scopes []discord.OAuth2Scope, opts ...rest.RequestOpt) (Session, error)
+	// RefreshSession refreshes the given Session with the refresh token. Session(s) started with the client credentials grant are started again instead
 	RefreshSession(identifier string, session Session, opts ...rest.RequestOpt) (Session, error)
+	// RevokeSession revokes the tokens of the given Session & deletes it from the SessionController
+	RevokeSession(session Session, opts ...rest.RequestOpt) error
 
 	// GetUser returns the discord.OAuth2User associated with the given Session. Fields filled in the struct depend on the Session.Scopes
+	// This and the following methods refresh the Session if WithAutoRefresh is enabled. The refreshed Session can be retrieved from the SessionController
 	GetUser(session Session, opts ...rest.RequestOpt) (*discord.OAuth2User, error)
 	// GetMember returns the discord.Member associated with the given Session in a specific guild.
 	GetMember(session Session, guildID snowflake.ID, opts ...rest.RequestOpt) (*discord.Member, error)
@@ -55,4 +68,8 @@ type Client interface {
 	GetGuilds(session Session, opts ...rest.RequestOpt) ([]discord.OAuth2Guild, error)
 	// GetConnections returns the discord.Connection(s) the user has connected. This requires the discord.OAuth2ScopeConnections scope in the Session
 	GetConnections(session Session, opts ...rest.RequestOpt) ([]discord.Connection, error)
+	// GetApplicationRoleConnection returns the discord.ApplicationRoleConnection of the user to this application. This requires the discord.OAuth2ScopeRoleConnectionsWrite scope in the Session
+	GetApplicationRoleConnection(session Session, opts ...rest.RequestOpt) (*discord.ApplicationRoleConnection, error)
+	// UpdateApplicationRoleConnection updates the discord.ApplicationRoleConnection of the user to this application which is used for linked roles. This requires the discord.OAuth2ScopeRoleConnectionsWrite scope in the Session
+	UpdateApplicationRoleConnection(session Session, connectionUpdate discord.ApplicationRoleConnectionUpdate, opts ...rest.RequestOpt) (*discord.ApplicationRoleConnection, error)
 }
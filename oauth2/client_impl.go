@@ -1,6 +1,11 @@
 package oauth2
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
@@ -14,13 +19,26 @@ func New(id snowflake.ID, secret string, opts ...ConfigOpt) Client {
 	config := DefaultConfig()
 	config.Apply(opts)
 
-	return &clientImpl{id: id, secret: secret, config: *config}
+	return &clientImpl{
+		id:        id,
+		secret:    secret,
+		config:    *config,
+		refreshes: map[string]*refreshLock{},
+	}
+}
+
+type refreshLock struct {
+	mu   sync.Mutex
+	refs int
 }
 
 type clientImpl struct {
 	id     snowflake.ID
 	secret string
 	config Config
+
+	refreshes   map[string]*refreshLock
+	refreshesMu sync.Mutex
 }
 
 func (c *clientImpl) ID() snowflake.ID {
@@ -43,13 +61,16 @@ func (c *clientImpl) StateController() StateController {
 	return c.config.StateController
 }
 
-func (c *clientImpl) GenerateAuthorizationURL(redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) string {
-	url, _ := c.GenerateAuthorizationURLState(redirectURI, permissions, guildID, disableGuildSelect, scopes...)
-	return url
+func (c *clientImpl) GenerateAuthorizationURL(ctx context.Context, redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, error) {
+	url, _, err := c.GenerateAuthorizationURLState(ctx, redirectURI, permissions, guildID, disableGuildSelect, scopes...)
+	return url, err
 }
 
-func (c *clientImpl) GenerateAuthorizationURLState(redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, string) {
-	state := c.StateController().GenerateNewState(redirectURI)
+func (c *clientImpl) GenerateAuthorizationURLState(ctx context.Context, redirectURI string, permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool, scopes ...discord.OAuth2Scope) (string, string, error) {
+	state, err := c.StateController().GenerateNewState(ctx, redirectURI)
+	if err != nil {
+		return "", "", err
+	}
 	values := route.QueryValues{
 		"client_id":     c.ID(),
 		"redirect_uri":  redirectURI,
@@ -67,65 +88,173 @@ func (c *clientImpl) GenerateAuthorizationURLState(redirectURI string, permissio
 		values["disable_guild_select"] = true
 	}
 	compiledRoute, _ := route.Authorize.Compile(values)
-	return compiledRoute.URL(), state
+	return compiledRoute.URL(), state, nil
 }
 
 func (c *clientImpl) StartSession(code string, state string, identifier string, opts ...rest.RequestOpt) (Session, error) {
-	redirectURI := c.StateController().ConsumeState(state)
-	if redirectURI == "" {
-		return nil, ErrStateNotFound
+	ctx := requestCtx(opts)
+	redirectURI, err := c.StateController().ConsumeState(ctx, state)
+	if err != nil {
+		return nil, err
 	}
 	exchange, err := c.Rest().GetAccessToken(c.id, c.secret, code, redirectURI, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return c.SessionController().CreateSessionFromResponse(identifier, *exchange), nil
+	return c.SessionController().CreateSessionFromResponse(ctx, identifier, discord.GrantTypeAuthorizationCode, *exchange)
+}
+
+func (c *clientImpl) StartClientCredentialsSession(identifier string, scopes []discord.OAuth2Scope, opts ...rest.RequestOpt) (Session, error) {
+	exchange, err := c.Rest().GetClientCredentialsAccessToken(c.id, c.secret, scopes, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.SessionController().CreateSessionFromResponse(requestCtx(opts), identifier, discord.GrantTypeClientCredentials, *exchange)
 }
 
 func (c *clientImpl) RefreshSession(identifier string, session Session, opts ...rest.RequestOpt) (Session, error) {
+	// the client credentials grant does not return a refresh token, so the Session is started again
+	if session.GrantType() == discord.GrantTypeClientCredentials {
+		return c.StartClientCredentialsSession(identifier, session.Scopes(), opts...)
+	}
+	if session.RefreshToken() == "" {
+		return nil, ErrNoRefreshToken
+	}
 	exchange, err := c.Rest().RefreshAccessToken(c.id, c.secret, session.RefreshToken(), opts...)
 	if err != nil {
 		return nil, err
 	}
-	return c.SessionController().CreateSessionFromResponse(identifier, *exchange), nil
+	// Session(s) stored before the grant type was persisted only have a refresh token when started with an authorization code
+	grantType := session.GrantType()
+	if grantType == "" {
+		grantType = discord.GrantTypeAuthorizationCode
+	}
+	return c.SessionController().CreateSessionFromResponse(requestCtx(opts), identifier, grantType, *exchange)
 }
 
-func (c *clientImpl) GetUser(session Session, opts ...rest.RequestOpt) (*discord.OAuth2User, error) {
-	if session.Expiration().Before(time.Now()) {
-		return nil, ErrAccessTokenExpired
+func (c *clientImpl) RevokeSession(session Session, opts ...rest.RequestOpt) error {
+	// revoking the refresh token also revokes all access tokens created with it
+	token, hint := session.RefreshToken(), discord.TokenTypeHintRefreshToken
+	if token == "" {
+		token, hint = session.AccessToken(), discord.TokenTypeHintAccessToken
+	}
+	if err := c.Rest().RevokeToken(c.id, c.secret, token, hint, opts...); err != nil {
+		return err
 	}
-	if !discord.HasScope(discord.OAuth2ScopeIdentify, session.Scopes()...) {
-		return nil, ErrMissingOAuth2Scope(discord.OAuth2ScopeIdentify)
+	if err := c.SessionController().DeleteSession(requestCtx(opts), session.Identifier()); err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return err
 	}
-	return c.Rest().GetCurrentUser(session.AccessToken(), opts...)
+	return nil
+}
+
+func (c *clientImpl) GetUser(session Session, opts ...rest.RequestOpt) (*discord.OAuth2User, error) {
+	return withSession(c, session, discord.OAuth2ScopeIdentify, opts, func(accessToken string) (*discord.OAuth2User, error) {
+		return c.Rest().GetCurrentUser(accessToken, opts...)
+	})
 }
 
 func (c *clientImpl) GetMember(session Session, guildID snowflake.ID, opts ...rest.RequestOpt) (*discord.Member, error) {
-	if session.Expiration().Before(time.Now()) {
-		return nil, ErrAccessTokenExpired
-	}
-	if !discord.HasScope(discord.OAuth2ScopeGuildsMembersRead, session.Scopes()...) {
-		return nil, ErrMissingOAuth2Scope(discord.OAuth2ScopeGuildsMembersRead)
-	}
-	return c.Rest().GetCurrentMember(session.AccessToken(), guildID, opts...)
+	return withSession(c, session, discord.OAuth2ScopeGuildsMembersRead, opts, func(accessToken string) (*discord.Member, error) {
+		return c.Rest().GetCurrentMember(accessToken, guildID, opts...)
+	})
 }
 
 func (c *clientImpl) GetGuilds(session Session, opts ...rest.RequestOpt) ([]discord.OAuth2Guild, error) {
+	return withSession(c, session, discord.OAuth2ScopeGuilds, opts, func(accessToken string) ([]discord.OAuth2Guild, error) {
+		return c.Rest().GetCurrentUserGuilds(accessToken, 0, 0, 0, opts...)
+	})
+}
+
+func (c *clientImpl) GetConnections(session Session, opts ...rest.RequestOpt) ([]discord.Connection, error) {
+	return withSession(c, session, discord.OAuth2ScopeConnections, opts, func(accessToken string) ([]discord.Connection, error) {
+		return c.Rest().GetCurrentUserConnections(accessToken, opts...)
+	})
+}
+
+func (c *clientImpl) GetApplicationRoleConnection(session Session, opts ...rest.RequestOpt) (*discord.ApplicationRoleConnection, error) {
+	return withSession(c, session, discord.OAuth2ScopeRoleConnectionsWrite, opts, func(accessToken string) (*discord.ApplicationRoleConnection, error) {
+		return c.Rest().GetCurrentUserApplicationRoleConnection(accessToken, c.id, opts...)
+	})
+}
+
+func (c *clientImpl) UpdateApplicationRoleConnection(session Session, connectionUpdate discord.ApplicationRoleConnectionUpdate, opts ...rest.RequestOpt) (*discord.ApplicationRoleConnection, error) {
+	return withSession(c, session, discord.OAuth2ScopeRoleConnectionsWrite, opts, func(accessToken string) (*discord.ApplicationRoleConnection, error) {
+		return c.Rest().UpdateCurrentUserApplicationRoleConnection(accessToken, c.id, connectionUpdate, opts...)
+	})
+}
+
+// withSession checks the scope & expiration of the Session and calls do with its access token.
+// If Config.AutoRefresh is enabled, expired Session(s) are refreshed and do is retried once with a refreshed Session when the access token got rejected.
+func withSession[T any](c *clientImpl, session Session, scope discord.OAuth2Scope, opts []rest.RequestOpt, do func(accessToken string) (T, error)) (T, error) {
+	var zero T
+	if !discord.HasScope(scope, session.Scopes()...) {
+		return zero, ErrMissingOAuth2Scope(scope)
+	}
 	if session.Expiration().Before(time.Now()) {
-		return nil, ErrAccessTokenExpired
+		if !c.config.AutoRefresh {
+			return zero, ErrAccessTokenExpired
+		}
+		var err error
+		if session, err = c.refresh(session, opts); err != nil {
+			return zero, err
+		}
+	}
+
+	v, err := do(session.AccessToken())
+	if !c.config.AutoRefresh || !isUnauthorized(err) {
+		return v, err
 	}
-	if !discord.HasScope(discord.OAuth2ScopeGuilds, session.Scopes()...) {
-		return nil, ErrMissingOAuth2Scope(discord.OAuth2ScopeGuilds)
+	if session, err = c.refresh(session, opts); err != nil {
+		return zero, err
 	}
-	return c.Rest().GetCurrentUserGuilds(session.AccessToken(), 0, 0, 0, opts...)
+	return do(session.AccessToken())
 }
 
-func (c *clientImpl) GetConnections(session Session, opts ...rest.RequestOpt) ([]discord.Connection, error) {
-	if session.Expiration().Before(time.Now()) {
-		return nil, ErrAccessTokenExpired
+// refresh refreshes the given Session unless it was already refreshed by someone else in the meantime.
+func (c *clientImpl) refresh(session Session, opts []rest.RequestOpt) (Session, error) {
+	identifier := session.Identifier()
+
+	c.refreshesMu.Lock()
+	lock, ok := c.refreshes[identifier]
+	if !ok {
+		lock = &refreshLock{}
+		c.refreshes[identifier] = lock
+	}
+	lock.refs++
+	c.refreshesMu.Unlock()
+
+	lock.mu.Lock()
+	defer func() {
+		lock.mu.Unlock()
+		c.refreshesMu.Lock()
+		if lock.refs--; lock.refs == 0 {
+			delete(c.refreshes, identifier)
+		}
+		c.refreshesMu.Unlock()
+	}()
+
+	// the refresh token can only be used once, so we use the Session another caller or instance stored already
+	stored, err := c.SessionController().GetSession(requestCtx(opts), identifier)
+	if err == nil && stored.AccessToken() != session.AccessToken() && !stored.Expiration().Before(time.Now()) {
+		return stored, nil
 	}
-	if !discord.HasScope(discord.OAuth2ScopeConnections, session.Scopes()...) {
-		return nil, ErrMissingOAuth2Scope(discord.OAuth2ScopeConnections)
+	if err != nil && !errors.Is(err, ErrSessionNotFound) {
+		return nil, err
 	}
-	return c.Rest().GetCurrentUserConnections(session.AccessToken(), opts...)
+	c.config.Logger.Debugf("refreshing oauth2 session %s", identifier)
+	return c.RefreshSession(identifier, session, opts...)
+}
+
+// isUnauthorized returns whether the error means the access token got rejected.
+func isUnauthorized(err error) bool {
+	var restErr *rest.Error
+	return errors.As(err, &restErr) && restErr.Response != nil && restErr.Response.StatusCode == http.StatusUnauthorized
+}
+
+// requestCtx returns the context.Context set by the given rest.RequestOpt(s), so the same one is used for the SessionController & StateController.
+func requestCtx(opts []rest.RequestOpt) context.Context {
+	// options like rest.WithHeader modify the request, so they need one even though it is discarded
+	config := rest.DefaultRequestConfig(&http.Request{Header: http.Header{}, URL: &url.URL{}})
+	config.Apply(opts)
+	return config.Ctx
 }
@@ -0,0 +1,121 @@
+package oauth2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type fakeOAuth2 struct {
+	rest.OAuth2
+	refreshes int
+	revoked   string
+	revokeErr error
+}
+
+func (f *fakeOAuth2) GetAccessToken(_ snowflake.ID, _ string, code string, _ string, _ ...rest.RequestOpt) (*discord.AccessTokenResponse, error) {
+	return &discord.AccessTokenResponse{
+		AccessToken:  code,
+		RefreshToken: "refresh",
+		Scope:        []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		ExpiresIn:    time.Hour,
+	}, nil
+}
+
+func (f *fakeOAuth2) RefreshAccessToken(_ snowflake.ID, _ string, refreshToken string, _ ...rest.RequestOpt) (*discord.AccessTokenResponse, error) {
+	f.refreshes++
+	return &discord.AccessTokenResponse{
+		AccessToken:  "refreshed",
+		RefreshToken: refreshToken,
+		Scope:        []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		ExpiresIn:    time.Hour,
+	}, nil
+}
+
+func (f *fakeOAuth2) GetClientCredentialsAccessToken(_ snowflake.ID, _ string, scopes []discord.OAuth2Scope, _ ...rest.RequestOpt) (*discord.AccessTokenResponse, error) {
+	return &discord.AccessTokenResponse{
+		AccessToken: "client_credentials",
+		Scope:       scopes,
+		ExpiresIn:   time.Hour,
+	}, nil
+}
+
+func (f *fakeOAuth2) GetCurrentUser(bearerToken string, _ ...rest.RequestOpt) (*discord.OAuth2User, error) {
+	return &discord.OAuth2User{User: discord.User{Username: bearerToken}}, nil
+}
+
+func (f *fakeOAuth2) RevokeToken(_ snowflake.ID, _ string, token string, _ discord.TokenTypeHint, _ ...rest.RequestOpt) error {
+	f.revoked = token
+	return f.revokeErr
+}
+
+func TestClient_AutoRefresh(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeOAuth2{}
+	client := New(0, "", WithOAuth2(fake), WithAutoRefresh())
+
+	expired, err := client.SessionController().CreateSession(ctx, "id", "expired", "refresh", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, discord.TokenTypeBearer, discord.GrantTypeAuthorizationCode, time.Now().Add(-time.Minute), nil, nil)
+	assert.NoError(t, err)
+
+	user, err := client.GetUser(expired)
+	assert.NoError(t, err)
+	assert.Equal(t, "refreshed", user.Username)
+
+	// the stale Session is refreshed from the SessionController instead of using the refresh token again
+	_, err = client.GetUser(expired)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fake.refreshes)
+
+	session, err := client.SessionController().GetSession(ctx, "id")
+	assert.NoError(t, err)
+	assert.NoError(t, client.RevokeSession(session))
+	assert.Equal(t, "refresh", fake.revoked)
+
+	_, err = client.SessionController().GetSession(ctx, "id")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestClient_RefreshClientCredentials(t *testing.T) {
+	client := New(0, "", WithOAuth2(&fakeOAuth2{}))
+
+	session, err := client.StartClientCredentialsSession("id", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify})
+	assert.NoError(t, err)
+	assert.Empty(t, session.RefreshToken())
+
+	assert.Equal(t, discord.GrantTypeClientCredentials, session.GrantType())
+
+	session, err = client.RefreshSession("id", session)
+	assert.NoError(t, err)
+	assert.Equal(t, "client_credentials", session.AccessToken())
+	assert.Equal(t, []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, session.Scopes())
+	assert.Equal(t, discord.GrantTypeClientCredentials, session.GrantType())
+
+	_, err = NewWebhookClient(session)
+	assert.Error(t, err)
+}
+
+func TestClient_RefreshWithoutRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeOAuth2{}
+	client := New(0, "", WithOAuth2(fake))
+
+	// user Session(s) without a refresh token must not be turned into a Session of the application owner
+	session, err := client.SessionController().CreateSession(ctx, "id", "access", "", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, discord.TokenTypeBearer, discord.GrantTypeAuthorizationCode, time.Now().Add(-time.Minute), nil, nil)
+	assert.NoError(t, err)
+	_, err = client.RefreshSession("id", session)
+	assert.ErrorIs(t, err, ErrNoRefreshToken)
+
+	session, err = client.SessionController().CreateSession(ctx, "id", "access", "refresh", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, discord.TokenTypeBearer, discord.GrantTypeAuthorizationCode, time.Now().Add(-time.Minute), nil, nil)
+	assert.NoError(t, err)
+	session, err = client.RefreshSession("id", session)
+	assert.NoError(t, err)
+	assert.Equal(t, "refreshed", session.AccessToken())
+	assert.Equal(t, discord.GrantTypeAuthorizationCode, session.GrantType())
+	assert.Equal(t, 1, fake.refreshes)
+}
@@ -8,20 +8,21 @@ import (
 // DefaultConfig is the configuration which is used by default
 func DefaultConfig() *Config {
 	return &Config{
-		Logger:            log.Default(),
-		SessionController: NewSessionController(),
+		Logger: log.Default(),
 	}
 }
 
 // Config is the configuration for the OAuth2 client
 type Config struct {
-	Logger                    log.Logger
-	RestClient                rest.Client
-	RestClientConfigOpts      []rest.ConfigOpt
-	OAuth2                    rest.OAuth2
-	SessionController         SessionController
-	StateController           StateController
-	StateControllerConfigOpts []StateControllerConfigOpt
+	Logger                      log.Logger
+	RestClient                  rest.Client
+	RestClientConfigOpts        []rest.ConfigOpt
+	OAuth2                      rest.OAuth2
+	SessionController           SessionController
+	SessionControllerConfigOpts []SessionControllerConfigOpt
+	StateController             StateController
+	StateControllerConfigOpts   []StateControllerConfigOpt
+	AutoRefresh                 bool
 }
 
 // ConfigOpt can be used to supply optional parameters to New
@@ -38,6 +39,9 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	if c.OAuth2 == nil {
 		c.OAuth2 = rest.NewOAuth2(c.RestClient)
 	}
+	if c.SessionController == nil {
+		c.SessionController = NewSessionController(c.SessionControllerConfigOpts...)
+	}
 	if c.StateController == nil {
 		c.StateController = NewStateController(c.StateControllerConfigOpts...)
 	}
@@ -78,6 +82,13 @@ func WithSessionController(sessionController SessionController) ConfigOpt {
 	}
 }
 
+// WithSessionControllerOpts applies all SessionControllerConfigOpt(s) to the SessionController
+func WithSessionControllerOpts(opts ...SessionControllerConfigOpt) ConfigOpt {
+	return func(config *Config) {
+		config.SessionControllerConfigOpts = append(config.SessionControllerConfigOpts, opts...)
+	}
+}
+
 // WithStateController applies a custom StateController to the OAuth2 client
 func WithStateController(stateController StateController) ConfigOpt {
 	return func(config *Config) {
@@ -91,3 +102,18 @@ func WithStateControllerOpts(opts ...StateControllerConfigOpt) ConfigOpt {
 		config.StateControllerConfigOpts = append(config.StateControllerConfigOpts, opts...)
 	}
 }
+
+// WithAutoRefresh refreshes expired Session(s) or Session(s) whose access token got rejected before retrying the request
+func WithAutoRefresh() ConfigOpt {
+	return func(config *Config) {
+		config.AutoRefresh = true
+	}
+}
+
+// WithStore persists the Session(s) & states of the default SessionController & StateController in the given Store
+func WithStore(store Store) ConfigOpt {
+	return func(config *Config) {
+		config.SessionControllerConfigOpts = append(config.SessionControllerConfigOpts, WithSessionStore(store))
+		config.StateControllerConfigOpts = append(config.StateControllerConfigOpts, WithStateStore(store))
+	}
+}
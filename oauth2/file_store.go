@@ -0,0 +1,140 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Store = (*fileStore)(nil)
+
+// NewFileStore returns a new Store which keeps every key in its own file in the given directory.
+// The directory is created if it does not exist. Expired files are removed when they are read and periodically when new keys are set.
+func NewFileStore(dir string) (Store, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &fileStore{dir: dir}, nil
+}
+
+// fileStoreTempPrefix is the prefix of files which are still being written.
+const fileStoreTempPrefix = ".tmp-"
+
+type fileStore struct {
+	dir string
+
+	lastCleanup time.Time
+	cleanupMu   sync.Mutex
+}
+
+func (s *fileStore) path(key string) string {
+	return filepath.Join(s.dir, base64.RawURLEncoding.EncodeToString([]byte(key)))
+}
+
+// read returns the value & expiration of the given file. Files start with the expiration as unix nanoseconds followed by the value.
+func (s *fileStore) read(path string) ([]byte, time.Time, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(data) < 8) {
+		return nil, time.Time{}, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	var expiresAt time.Time
+	if nanos := int64(binary.BigEndian.Uint64(data)); nanos != 0 {
+		expiresAt = time.Unix(0, nanos)
+	}
+	return data[8:], expiresAt, nil
+}
+
+func (s *fileStore) Get(_ context.Context, key string) ([]byte, error) {
+	path := s.path(key)
+	value, expiresAt, err := s.read(path)
+	if err != nil {
+		return nil, err
+	}
+	if (storeValue{expiresAt: expiresAt}).expired(time.Now()) {
+		_ = os.Remove(path)
+		return nil, ErrKeyNotFound
+	}
+	return value, nil
+}
+
+func (s *fileStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	now := time.Now()
+	s.cleanup(now)
+
+	data := make([]byte, 8+len(value))
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(data, uint64(now.Add(ttl).UnixNano()))
+	}
+	copy(data[8:], value)
+
+	// write to a temporary file first, so readers never see a partially written value
+	file, err := os.CreateTemp(s.dir, fileStoreTempPrefix+"*")
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err = file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	if err = os.Rename(file.Name(), s.path(key)); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return nil
+}
+
+func (s *fileStore) Delete(_ context.Context, key string) error {
+	path := s.path(key)
+	_, expiresAt, err := s.read(path)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); errors.Is(err, os.ErrNotExist) {
+		return ErrKeyNotFound
+	} else if err != nil {
+		return err
+	}
+	if (storeValue{expiresAt: expiresAt}).expired(time.Now()) {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+// cleanup removes all expired files once per minute.
+func (s *fileStore) cleanup(now time.Time) {
+	s.cleanupMu.Lock()
+	if now.Sub(s.lastCleanup) < time.Minute {
+		s.cleanupMu.Unlock()
+		return
+	}
+	s.lastCleanup = now
+	s.cleanupMu.Unlock()
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), fileStoreTempPrefix) {
+			continue
+		}
+		path := filepath.Join(s.dir, entry.Name())
+		if _, expiresAt, err := s.read(path); err == nil && (storeValue{expiresAt: expiresAt}).expired(now) {
+			_ = os.Remove(path)
+		}
+	}
+}
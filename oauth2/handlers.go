@@ -0,0 +1,404 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+)
+
+var (
+	// ErrInvalidCookie is returned when the session cookie is missing or its signature is invalid.
+	ErrInvalidCookie = errors.New("session cookie is missing or invalid")
+
+	// ErrMissingRedirectURI is returned by the login handler when no redirect URI is configured.
+	ErrMissingRedirectURI = errors.New("no redirect uri configured")
+
+	// ErrStateMismatch is returned by the callback handler when the state does not match the state cookie set by the login handler in the same browser.
+	ErrStateMismatch = errors.New("state does not match the state cookie")
+)
+
+// AuthorizationError is returned by the callback handler when the user denied the authorization or it failed otherwise.
+type AuthorizationError struct {
+	Code        string
+	Description string
+}
+
+func (e AuthorizationError) Error() string {
+	if e.Description == "" {
+		return "authorization failed: " + e.Code
+	}
+	return fmt.Sprintf("authorization failed: %s: %s", e.Code, e.Description)
+}
+
+// LoginFunc is called by the callback handler after a Session was started.
+// The user is only fetched if the discord.OAuth2ScopeIdentify was authorized and nil otherwise.
+type LoginFunc func(w http.ResponseWriter, r *http.Request, session Session, user *discord.OAuth2User)
+
+// ErrorFunc is called by the Handlers when a request failed.
+type ErrorFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorHandler responds with 400 Bad Request for invalid states & failed authorizations and 500 Internal Server Error otherwise.
+func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	var authErr AuthorizationError
+	if errors.Is(err, ErrStateNotFound) || errors.Is(err, ErrStateMismatch) || errors.As(err, &authErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+// Handlers are net/http handlers for the OAuth2 authorization flow.
+// The identifier of the Session is stored in a signed cookie after the login.
+type Handlers interface {
+	// Login redirects to the authorization URL. The state is stored in a signed cookie, so the Callback only accepts it from the same browser.
+	Login() http.Handler
+
+	// Callback validates the state against the state cookie, starts the Session, sets the session cookie and calls the LoginFunc.
+	// It must be served at the configured redirect URI.
+	Callback() http.Handler
+
+	// Logout removes the session cookie, revokes the Session and redirects to the configured logout URL.
+	Logout() http.Handler
+
+	// Middleware loads the Session of the session cookie into the context.Context of the request. Use SessionFromContext to get it.
+	// Requests without a valid Session are passed on without one.
+	Middleware(next http.Handler) http.Handler
+}
+
+// DefaultHandlersConfig returns a HandlersConfig with sensible defaults.
+func DefaultHandlersConfig() *HandlersConfig {
+	return &HandlersConfig{
+		Scopes: []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		Cookie: http.Cookie{
+			Name:     "disgo_session",
+			Path:     "/",
+			MaxAge:   int((30 * 24 * time.Hour).Seconds()),
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		},
+		OnLogin: func(w http.ResponseWriter, r *http.Request, _ Session, _ *discord.OAuth2User) {
+			http.Redirect(w, r, "/", http.StatusFound)
+		},
+		LoginTimeout:      10 * time.Minute,
+		OnError:           DefaultErrorHandler,
+		LogoutRedirectURL: "/",
+	}
+}
+
+// HandlersConfig lets you configure your Handlers instance.
+type HandlersConfig struct {
+	// RedirectURI is the URI the Callback handler is served at.
+	RedirectURI        string
+	Scopes             []discord.OAuth2Scope
+	Permissions        discord.Permissions
+	GuildID            snowflake.ID
+	DisableGuildSelect bool
+
+	// Cookie is the template of the session cookie. Its value is set by the Handlers.
+	Cookie http.Cookie
+
+	// CookieSecret is used to sign the session & state cookie. If none is set a random one is generated, which means the cookies are only valid until restart and on this instance.
+	CookieSecret []byte
+
+	// LoginTimeout is how long the user has to authorize after the Login handler before the state cookie expires.
+	LoginTimeout time.Duration
+
+	OnLogin           LoginFunc
+	OnError           ErrorFunc
+	LogoutRedirectURL string
+}
+
+// HandlersConfigOpt is a type alias for a function that takes a HandlersConfig and is used to configure your Handlers.
+type HandlersConfigOpt func(config *HandlersConfig)
+
+// Apply applies the given HandlersConfigOpt(s) to the HandlersConfig
+func (c *HandlersConfig) Apply(opts []HandlersConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.CookieSecret) == 0 {
+		c.CookieSecret = make([]byte, 32)
+		_, _ = rand.Read(c.CookieSecret)
+	}
+}
+
+// WithRedirectURI sets the URI the Callback handler is served at. It needs to be added to your application in the developer portal.
+func WithRedirectURI(redirectURI string) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.RedirectURI = redirectURI
+	}
+}
+
+// WithScopes sets the discord.OAuth2Scope(s) to authorize.
+func WithScopes(scopes ...discord.OAuth2Scope) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.Scopes = scopes
+	}
+}
+
+// WithBotAuthorization sets the discord.Permissions, guild and whether the guild can be changed when the discord.OAuth2ScopeBot is authorized.
+func WithBotAuthorization(permissions discord.Permissions, guildID snowflake.ID, disableGuildSelect bool) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.Permissions = permissions
+		config.GuildID = guildID
+		config.DisableGuildSelect = disableGuildSelect
+	}
+}
+
+// WithCookie sets the template of the session cookie.
+func WithCookie(cookie http.Cookie) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.Cookie = cookie
+	}
+}
+
+// WithCookieSecret sets the secret used to sign the session cookie. All instances of your application need to use the same one.
+func WithCookieSecret(secret []byte) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.CookieSecret = secret
+	}
+}
+
+// WithLoginTimeout sets how long the user has to authorize after the Login handler before the state cookie expires.
+func WithLoginTimeout(loginTimeout time.Duration) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.LoginTimeout = loginTimeout
+	}
+}
+
+// WithOnLogin sets the LoginFunc which is called after a Session was started. By default, it redirects to /.
+func WithOnLogin(onLogin LoginFunc) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.OnLogin = onLogin
+	}
+}
+
+// WithOnError sets the ErrorFunc which is called when a request failed.
+func WithOnError(onError ErrorFunc) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.OnError = onError
+	}
+}
+
+// WithLogoutRedirectURL sets the URL the Logout handler redirects to.
+func WithLogoutRedirectURL(logoutRedirectURL string) HandlersConfigOpt {
+	return func(config *HandlersConfig) {
+		config.LogoutRedirectURL = logoutRedirectURL
+	}
+}
+
+type sessionContextKey struct{}
+
+// SessionFromContext returns the Session loaded by Handlers.Middleware or nil if the request has none.
+func SessionFromContext(ctx context.Context) Session {
+	session, _ := ctx.Value(sessionContextKey{}).(Session)
+	return session
+}
+
+var _ Handlers = (*handlersImpl)(nil)
+
+// NewHandlers creates new Handlers for the given Client with the given HandlersConfigOpt(s).
+func NewHandlers(client Client, opts ...HandlersConfigOpt) Handlers {
+	config := DefaultHandlersConfig()
+	config.Apply(opts)
+
+	return &handlersImpl{
+		client: client,
+		config: *config,
+	}
+}
+
+type handlersImpl struct {
+	client Client
+	config HandlersConfig
+}
+
+func (h *handlersImpl) Login() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h.config.RedirectURI == "" {
+			h.config.OnError(w, r, ErrMissingRedirectURI)
+			return
+		}
+		url, state, err := h.client.GenerateAuthorizationURLState(r.Context(), h.config.RedirectURI, h.config.Permissions, h.config.GuildID, h.config.DisableGuildSelect, h.config.Scopes...)
+		if err != nil {
+			h.config.OnError(w, r, err)
+			return
+		}
+
+		cookie := h.stateCookie()
+		cookie.Value = h.sign(stateCookiePrefix + strconv.FormatInt(time.Now().Add(h.config.LoginTimeout).Unix(), 10) + "." + state)
+		cookie.MaxAge = int(h.config.LoginTimeout.Seconds())
+		http.SetCookie(w, &cookie)
+		http.Redirect(w, r, url, http.StatusFound)
+	})
+}
+
+func (h *handlersImpl) Callback() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the state can only be used once, so the state cookie is removed no matter the outcome
+		state, stateErr := h.state(r)
+		cookie := h.stateCookie()
+		cookie.MaxAge = -1
+		http.SetCookie(w, &cookie)
+
+		query := r.URL.Query()
+		if code := query.Get("error"); code != "" {
+			h.config.OnError(w, r, AuthorizationError{Code: code, Description: query.Get("error_description")})
+			return
+		}
+		if stateErr != nil || state != query.Get("state") {
+			h.config.OnError(w, r, ErrStateMismatch)
+			return
+		}
+
+		identifier, err := newIdentifier()
+		if err != nil {
+			h.config.OnError(w, r, err)
+			return
+		}
+		session, err := h.client.StartSession(query.Get("code"), query.Get("state"), identifier, rest.WithCtx(r.Context()))
+		if err != nil {
+			h.config.OnError(w, r, err)
+			return
+		}
+
+		var user *discord.OAuth2User
+		if discord.HasScope(discord.OAuth2ScopeIdentify, session.Scopes()...) {
+			if user, err = h.client.GetUser(session, rest.WithCtx(r.Context())); err != nil {
+				h.config.OnError(w, r, err)
+				return
+			}
+		}
+
+		cookie = h.config.Cookie
+		cookie.Value = h.sign(identifier)
+		http.SetCookie(w, &cookie)
+		h.config.OnLogin(w, r, session, user)
+	})
+}
+
+func (h *handlersImpl) Logout() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the user is logged out of this application even if Discord could not revoke the tokens
+		cookie := h.config.Cookie
+		cookie.MaxAge = -1
+		http.SetCookie(w, &cookie)
+
+		if session, err := h.session(r); err == nil {
+			if err = h.client.RevokeSession(session, rest.WithCtx(r.Context())); err != nil {
+				h.config.OnError(w, r, err)
+				return
+			}
+		}
+		http.Redirect(w, r, h.config.LogoutRedirectURL, http.StatusFound)
+	})
+}
+
+func (h *handlersImpl) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := h.session(r)
+		if err != nil && !errors.Is(err, ErrInvalidCookie) && !errors.Is(err, ErrSessionNotFound) {
+			h.config.OnError(w, r, err)
+			return
+		}
+		if session != nil {
+			r = r.WithContext(context.WithValue(r.Context(), sessionContextKey{}, session))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// session returns the Session of the session cookie of the request.
+func (h *handlersImpl) session(r *http.Request) (Session, error) {
+	cookie, err := r.Cookie(h.config.Cookie.Name)
+	if err != nil {
+		return nil, ErrInvalidCookie
+	}
+	identifier, ok := h.verify(cookie.Value)
+	if !ok {
+		return nil, ErrInvalidCookie
+	}
+	return h.client.SessionController().GetSession(r.Context(), identifier)
+}
+
+// stateCookiePrefix is prepended to the signed value of the state cookie, so it can't be used as session cookie and vice versa.
+const stateCookiePrefix = "state."
+
+// stateCookie returns the template of the state cookie. It is sent along the redirect from Discord, so it uses http.SameSiteLaxMode.
+func (h *handlersImpl) stateCookie() http.Cookie {
+	return http.Cookie{
+		Name:     h.config.Cookie.Name + "_state",
+		Path:     h.config.Cookie.Path,
+		Domain:   h.config.Cookie.Domain,
+		Secure:   h.config.Cookie.Secure,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// state returns the state of the state cookie of the request if it is valid and not expired.
+func (h *handlersImpl) state(r *http.Request) (string, error) {
+	cookie, err := r.Cookie(h.stateCookie().Name)
+	if err != nil {
+		return "", ErrInvalidCookie
+	}
+	value, ok := h.verify(cookie.Value)
+	if !ok || !strings.HasPrefix(value, stateCookiePrefix) {
+		return "", ErrInvalidCookie
+	}
+	expiresAt, state, ok := strings.Cut(strings.TrimPrefix(value, stateCookiePrefix), ".")
+	if !ok {
+		return "", ErrInvalidCookie
+	}
+	if unix, err := strconv.ParseInt(expiresAt, 10, 64); err != nil || time.Now().After(time.Unix(unix, 0)) {
+		return "", ErrInvalidCookie
+	}
+	return state, nil
+}
+
+// sign returns the identifier followed by its signature.
+func (h *handlersImpl) sign(identifier string) string {
+	return identifier + "." + base64.RawURLEncoding.EncodeToString(h.mac(identifier))
+}
+
+// verify returns the identifier of a signed value and whether the signature is valid.
+func (h *handlersImpl) verify(value string) (string, bool) {
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 {
+		return "", false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(value[i+1:])
+	if err != nil || !hmac.Equal(signature, h.mac(value[:i])) {
+		return "", false
+	}
+	return value[:i], true
+}
+
+func (h *handlersImpl) mac(identifier string) []byte {
+	mac := hmac.New(sha256.New, h.config.CookieSecret)
+	mac.Write([]byte(identifier))
+	return mac.Sum(nil)
+}
+
+// newIdentifier returns a new random Session identifier which can't be guessed.
+func newIdentifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,177 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestHandlers_Middleware(t *testing.T) {
+	client := New(0, "", WithOAuth2(&fakeOAuth2{}))
+	handlers := NewHandlers(client, WithCookieSecret([]byte("secret"))).(*handlersImpl)
+
+	_, err := client.SessionController().CreateSession(context.Background(), "id", "access", "", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, discord.TokenTypeBearer, discord.GrantTypeAuthorizationCode, time.Now().Add(time.Hour), nil, nil)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		value   string
+		session bool
+	}{
+		{name: "valid", value: handlers.sign("id"), session: true},
+		{name: "tampered", value: "other" + handlers.sign("id")[2:]},
+		{name: "unsigned", value: "id"},
+		{name: "unknown", value: handlers.sign("unknown")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var session Session
+			h := handlers.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				session = SessionFromContext(r.Context())
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.AddCookie(&http.Cookie{Name: "disgo_session", Value: tt.value})
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			assert.Equal(t, tt.session, session != nil)
+		})
+	}
+}
+
+// cookie returns the cookie with the given name set by the response.
+func cookie(rs *http.Response, name string) *http.Cookie {
+	for _, c := range rs.Cookies() {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// login calls the Login handler and returns the state of the authorization URL and the state cookie.
+func login(t *testing.T, handlers Handlers) (string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	handlers.Login().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/login", nil))
+	rs := w.Result()
+	require.Equal(t, http.StatusFound, rs.StatusCode)
+
+	location, err := url.Parse(rs.Header.Get("Location"))
+	require.NoError(t, err)
+	state := location.Query().Get("state")
+	require.NotEmpty(t, state)
+
+	stateCookie := cookie(rs, "disgo_session_state")
+	require.NotNil(t, stateCookie)
+	return state, stateCookie
+}
+
+func TestHandlers_Login(t *testing.T) {
+	client := New(0, "", WithOAuth2(&fakeOAuth2{}))
+
+	w := httptest.NewRecorder()
+	NewHandlers(client).Login().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/login", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	handlers := NewHandlers(client, WithRedirectURI("https://example.com/callback"), WithLoginTimeout(5*time.Minute))
+	state, stateCookie := login(t, handlers)
+	assert.Equal(t, 300, stateCookie.MaxAge)
+	assert.True(t, stateCookie.HttpOnly)
+	assert.Equal(t, http.SameSiteLaxMode, stateCookie.SameSite)
+
+	r := httptest.NewRequest(http.MethodGet, "/callback", nil)
+	r.AddCookie(stateCookie)
+	verified, err := handlers.(*handlersImpl).state(r)
+	assert.NoError(t, err)
+	assert.Equal(t, state, verified)
+}
+
+func TestHandlers_Callback(t *testing.T) {
+	client := New(0, "", WithOAuth2(&fakeOAuth2{}))
+	handlers := NewHandlers(client, WithRedirectURI("https://example.com/callback"), WithCookieSecret([]byte("secret")))
+
+	callback := func(state string, stateCookie *http.Cookie) *http.Response {
+		r := httptest.NewRequest(http.MethodGet, "/callback?code=access&state="+url.QueryEscape(state), nil)
+		if stateCookie != nil {
+			r.AddCookie(stateCookie)
+		}
+		w := httptest.NewRecorder()
+		handlers.Callback().ServeHTTP(w, r)
+		return w.Result()
+	}
+
+	state, stateCookie := login(t, handlers)
+	otherState, otherCookie := login(t, handlers)
+	expiredCookie := *stateCookie
+	expiredCookie.Value = handlers.(*handlersImpl).sign(stateCookiePrefix + strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10) + "." + state)
+
+	tests := []struct {
+		name        string
+		state       string
+		stateCookie *http.Cookie
+	}{
+		{name: "missing cookie", state: state},
+		{name: "cookie of another login", state: state, stateCookie: otherCookie},
+		{name: "expired cookie", state: state, stateCookie: &expiredCookie},
+		{name: "session cookie", state: state, stateCookie: &http.Cookie{Name: "disgo_session_state", Value: handlers.(*handlersImpl).sign(state)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := callback(tt.state, tt.stateCookie)
+			assert.Equal(t, http.StatusBadRequest, rs.StatusCode)
+			assert.Nil(t, cookie(rs, "disgo_session"))
+			// the state cookie is removed in any case
+			require.NotNil(t, cookie(rs, "disgo_session_state"))
+			assert.Equal(t, -1, cookie(rs, "disgo_session_state").MaxAge)
+		})
+	}
+
+	rs := callback(state, stateCookie)
+	assert.Equal(t, http.StatusFound, rs.StatusCode)
+	assert.Equal(t, -1, cookie(rs, "disgo_session_state").MaxAge)
+	sessionCookie := cookie(rs, "disgo_session")
+	require.NotNil(t, sessionCookie)
+	identifier, ok := handlers.(*handlersImpl).verify(sessionCookie.Value)
+	require.True(t, ok)
+	session, err := client.SessionController().GetSession(context.Background(), identifier)
+	require.NoError(t, err)
+	assert.Equal(t, "access", session.AccessToken())
+
+	// the state was consumed by the first callback
+	rs = callback(state, stateCookie)
+	assert.Equal(t, http.StatusBadRequest, rs.StatusCode)
+
+	rs = callback(otherState, otherCookie)
+	assert.Equal(t, http.StatusFound, rs.StatusCode)
+}
+
+func TestHandlers_Logout(t *testing.T) {
+	fake := &fakeOAuth2{revokeErr: errors.New("discord unavailable")}
+	client := New(0, "", WithOAuth2(fake))
+	handlers := NewHandlers(client, WithCookieSecret([]byte("secret"))).(*handlersImpl)
+
+	_, err := client.SessionController().CreateSession(context.Background(), "id", "access", "refresh", []discord.OAuth2Scope{discord.OAuth2ScopeIdentify}, discord.TokenTypeBearer, discord.GrantTypeAuthorizationCode, time.Now().Add(time.Hour), nil, nil)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/logout", nil)
+	r.AddCookie(&http.Cookie{Name: "disgo_session", Value: handlers.sign("id")})
+	w := httptest.NewRecorder()
+	handlers.Logout().ServeHTTP(w, r)
+	rs := w.Result()
+
+	assert.Equal(t, http.StatusInternalServerError, rs.StatusCode)
+	assert.Equal(t, "refresh", fake.revoked)
+	// the session cookie is removed even though revoking the Session failed
+	sessionCookie := cookie(rs, "disgo_session")
+	require.NotNil(t, sessionCookie)
+	assert.Equal(t, -1, sessionCookie.MaxAge)
+}
@@ -4,12 +4,16 @@ import (
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
 )
 
 var _ Session = (*sessionImpl)(nil)
 
 // Session represents a discord access token response (https://discord.com/developers/docs/topics/oauth2#authorization-code-grant-access-token-response)
 type Session interface {
+	// Identifier returns the identifier the Session is stored with in the SessionController
+	Identifier() string
+
 	// AccessToken allows requesting user information
 	AccessToken() string
 
@@ -22,20 +26,33 @@ type Session interface {
 	// TokenType returns the discord.TokenType of the AccessToken
 	TokenType() discord.TokenType
 
+	// GrantType returns the discord.GrantType the Session was started with. It decides how the Session is refreshed
+	GrantType() discord.GrantType
+
 	// Expiration returns the time.Time when the AccessToken expires and needs to be refreshed
 	Expiration() time.Time
 
 	// Webhook returns the discord.IncomingWebhook when the discord.OAuth2ScopeWebhookIncoming is set
 	Webhook() *discord.IncomingWebhook
+
+	// Guild returns the discord.RestGuild the bot was added to when the discord.OAuth2ScopeBot is set
+	Guild() *discord.RestGuild
 }
 
 type sessionImpl struct {
+	identifier   string
 	accessToken  string
 	refreshToken string
 	scopes       []discord.OAuth2Scope
 	tokenType    discord.TokenType
+	grantType    discord.GrantType
 	expiration   time.Time
 	webhook      *discord.IncomingWebhook
+	guild        *discord.RestGuild
+}
+
+func (s *sessionImpl) Identifier() string {
+	return s.identifier
 }
 
 func (s *sessionImpl) AccessToken() string {
@@ -54,6 +71,10 @@ func (s *sessionImpl) TokenType() discord.TokenType {
 	return s.tokenType
 }
 
+func (s *sessionImpl) GrantType() discord.GrantType {
+	return s.grantType
+}
+
 func (s *sessionImpl) Expiration() time.Time {
 	return s.expiration
 }
@@ -61,3 +82,50 @@ func (s *sessionImpl) Expiration() time.Time {
 func (s *sessionImpl) Webhook() *discord.IncomingWebhook {
 	return s.webhook
 }
+
+func (s *sessionImpl) Guild() *discord.RestGuild {
+	return s.guild
+}
+
+// sessionJSON is how a Session is persisted in a Store.
+type sessionJSON struct {
+	AccessToken  string                   `json:"access_token"`
+	RefreshToken string                   `json:"refresh_token"`
+	Scopes       []discord.OAuth2Scope    `json:"scopes"`
+	TokenType    discord.TokenType        `json:"token_type"`
+	GrantType    discord.GrantType        `json:"grant_type,omitempty"`
+	Expiration   time.Time                `json:"expiration"`
+	Webhook      *discord.IncomingWebhook `json:"webhook,omitempty"`
+	Guild        *discord.RestGuild       `json:"guild,omitempty"`
+}
+
+func marshalSession(session Session) ([]byte, error) {
+	return json.Marshal(sessionJSON{
+		AccessToken:  session.AccessToken(),
+		RefreshToken: session.RefreshToken(),
+		Scopes:       session.Scopes(),
+		TokenType:    session.TokenType(),
+		GrantType:    session.GrantType(),
+		Expiration:   session.Expiration(),
+		Webhook:      session.Webhook(),
+		Guild:        session.Guild(),
+	})
+}
+
+func unmarshalSession(identifier string, data []byte) (Session, error) {
+	var v sessionJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &sessionImpl{
+		identifier:   identifier,
+		accessToken:  v.AccessToken,
+		refreshToken: v.RefreshToken,
+		scopes:       v.Scopes,
+		tokenType:    v.TokenType,
+		grantType:    v.GrantType,
+		expiration:   v.Expiration,
+		webhook:      v.Webhook,
+		guild:        v.Guild,
+	}, nil
+}
@@ -1,6 +1,8 @@
 package oauth2
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/disgoorg/disgo/discord"
@@ -10,49 +12,90 @@ var _ SessionController = (*sessionControllerImpl)(nil)
 
 // SessionController lets you manage your Session(s)
 type SessionController interface {
-	// GetSession returns the Session for the given identifier or nil if none was found
-	GetSession(identifier string) Session
+	// GetSession returns the Session for the given identifier or ErrSessionNotFound if none was found
+	GetSession(ctx context.Context, identifier string) (Session, error)
 
-	// CreateSession creates a new Session from the given identifier, access token, refresh token, scope, token type, expiration and webhook
-	CreateSession(identifier string, accessToken string, refreshToken string, scopes []discord.OAuth2Scope, tokenType discord.TokenType, expiration time.Time, webhook *discord.IncomingWebhook) Session
+	// CreateSession creates a new Session from the given identifier, access token, refresh token, scope, token type, grant type, expiration, webhook and guild
+	CreateSession(ctx context.Context, identifier string, accessToken string, refreshToken string, scopes []discord.OAuth2Scope, tokenType discord.TokenType, grantType discord.GrantType, expiration time.Time, webhook *discord.IncomingWebhook, guild *discord.RestGuild) (Session, error)
 
-	// CreateSessionFromResponse creates a new Session from the given identifier and discord.AccessTokenResponse payload
-	CreateSessionFromResponse(identifier string, response discord.AccessTokenResponse) Session
+	// CreateSessionFromResponse creates a new Session from the given identifier, discord.GrantType the Session was started with and discord.AccessTokenResponse payload
+	CreateSessionFromResponse(ctx context.Context, identifier string, grantType discord.GrantType, response discord.AccessTokenResponse) (Session, error)
+
+	// DeleteSession deletes the Session for the given identifier or returns ErrSessionNotFound if none was found
+	DeleteSession(ctx context.Context, identifier string) error
 }
 
-// NewSessionController returns a new empty SessionController
-func NewSessionController() SessionController {
-	return NewSessionControllerWithSessions(map[string]Session{})
+// NewSessionController returns a new SessionController which persists the Session(s) in the configured Store
+func NewSessionController(opts ...SessionControllerConfigOpt) SessionController {
+	config := DefaultSessionControllerConfig()
+	config.Apply(opts)
+
+	c := &sessionControllerImpl{store: config.Store}
+	for identifier, session := range config.Sessions {
+		_ = c.putSession(context.Background(), identifier, session)
+	}
+	return c
 }
 
-// NewSessionControllerWithSessions returns a new SessionController with the given Session(s)
+// NewSessionControllerWithSessions returns a new in-memory SessionController with the given Session(s)
 func NewSessionControllerWithSessions(sessions map[string]Session) SessionController {
-	return &sessionControllerImpl{sessions: sessions}
+	return NewSessionController(WithSessions(sessions))
 }
 
 type sessionControllerImpl struct {
-	sessions map[string]Session
+	store Store
 }
 
-func (c *sessionControllerImpl) GetSession(identifier string) Session {
-	return c.sessions[identifier]
+func sessionKey(identifier string) string {
+	return "session:" + identifier
 }
 
-func (c *sessionControllerImpl) CreateSession(identifier string, accessToken string, refreshToken string, scopes []discord.OAuth2Scope, tokenType discord.TokenType, expiration time.Time, webhook *discord.IncomingWebhook) Session {
+func (c *sessionControllerImpl) putSession(ctx context.Context, identifier string, session Session) error {
+	data, err := marshalSession(session)
+	if err != nil {
+		return err
+	}
+	return c.store.Set(ctx, sessionKey(identifier), data, 0)
+}
+
+func (c *sessionControllerImpl) GetSession(ctx context.Context, identifier string) (Session, error) {
+	data, err := c.store.Get(ctx, sessionKey(identifier))
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, ErrSessionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return unmarshalSession(identifier, data)
+}
+
+func (c *sessionControllerImpl) CreateSession(ctx context.Context, identifier string, accessToken string, refreshToken string, scopes []discord.OAuth2Scope, tokenType discord.TokenType, grantType discord.GrantType, expiration time.Time, webhook *discord.IncomingWebhook, guild *discord.RestGuild) (Session, error) {
 	session := &sessionImpl{
+		identifier:   identifier,
 		accessToken:  accessToken,
 		refreshToken: refreshToken,
 		scopes:       scopes,
 		tokenType:    tokenType,
+		grantType:    grantType,
 		expiration:   expiration,
 		webhook:      webhook,
+		guild:        guild,
 	}
+	if err := c.putSession(ctx, identifier, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
 
-	c.sessions[identifier] = session
-
-	return session
+func (c *sessionControllerImpl) CreateSessionFromResponse(ctx context.Context, identifier string, grantType discord.GrantType, response discord.AccessTokenResponse) (Session, error) {
+	// ExpiresIn is already converted to a time.Duration when unmarshalling the response
+	return c.CreateSession(ctx, identifier, response.AccessToken, response.RefreshToken, response.Scope, response.TokenType, grantType, time.Now().Add(response.ExpiresIn), response.Webhook, response.Guild)
 }
 
-func (c *sessionControllerImpl) CreateSessionFromResponse(identifier string, response discord.AccessTokenResponse) Session {
-	return c.CreateSession(identifier, response.AccessToken, response.RefreshToken, response.Scope, response.TokenType, time.Now().Add(response.ExpiresIn*time.Second), response.Webhook)
+func (c *sessionControllerImpl) DeleteSession(ctx context.Context, identifier string) error {
+	if err := c.store.Delete(ctx, sessionKey(identifier)); errors.Is(err, ErrKeyNotFound) {
+		return ErrSessionNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
 }
@@ -0,0 +1,41 @@
+package oauth2
+
+// DefaultSessionControllerConfig is the default configuration for the SessionController
+func DefaultSessionControllerConfig() *SessionControllerConfig {
+	return &SessionControllerConfig{
+		Sessions: map[string]Session{},
+	}
+}
+
+// SessionControllerConfig is the configuration for the SessionController
+type SessionControllerConfig struct {
+	Store    Store
+	Sessions map[string]Session
+}
+
+// SessionControllerConfigOpt is used to pass optional parameters to NewSessionController
+type SessionControllerConfigOpt func(config *SessionControllerConfig)
+
+// Apply applies the given SessionControllerConfigOpt(s) to the SessionControllerConfig
+func (c *SessionControllerConfig) Apply(opts []SessionControllerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.Store == nil {
+		c.Store = NewMemoryStore()
+	}
+}
+
+// WithSessionStore sets the Store the Session(s) are persisted in
+func WithSessionStore(store Store) SessionControllerConfigOpt {
+	return func(config *SessionControllerConfig) {
+		config.Store = store
+	}
+}
+
+// WithSessions loads Session(s) from an existing map into the Store. Errors of the Store are ignored, so use CreateSession for persistent Store(s)
+func WithSessions(sessions map[string]Session) SessionControllerConfigOpt {
+	return func(config *SessionControllerConfig) {
+		config.Sessions = sessions
+	}
+}
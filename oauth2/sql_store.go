@@ -0,0 +1,144 @@
+package oauth2
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// DefaultSQLStoreConfig returns a SQLStoreConfig with sensible defaults.
+func DefaultSQLStoreConfig() *SQLStoreConfig {
+	return &SQLStoreConfig{
+		Table:       "disgo_oauth2",
+		Placeholder: func(int) string { return "?" },
+	}
+}
+
+// SQLStoreConfig lets you configure your SQL Store instance.
+type SQLStoreConfig struct {
+	// Table is the name of the table the values are stored in.
+	Table string
+
+	// Placeholder returns the placeholder for the nth (starting at 1) parameter of a query as used by your database driver.
+	Placeholder func(n int) string
+}
+
+// SQLStoreConfigOpt is a type alias for a function that takes a SQLStoreConfig and is used to configure your SQL Store.
+type SQLStoreConfigOpt func(config *SQLStoreConfig)
+
+// Apply applies the given SQLStoreConfigOpt(s) to the SQLStoreConfig
+func (c *SQLStoreConfig) Apply(opts []SQLStoreConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithSQLTable sets the name of the table the values are stored in.
+func WithSQLTable(table string) SQLStoreConfigOpt {
+	return func(config *SQLStoreConfig) {
+		config.Table = table
+	}
+}
+
+// WithSQLPlaceholder sets the function which returns the placeholder for the nth parameter of a query.
+func WithSQLPlaceholder(placeholder func(n int) string) SQLStoreConfigOpt {
+	return func(config *SQLStoreConfig) {
+		config.Placeholder = placeholder
+	}
+}
+
+// WithSQLDollarPlaceholders uses $1, $2, ... as placeholders like PostgreSQL does.
+func WithSQLDollarPlaceholders() SQLStoreConfigOpt {
+	return WithSQLPlaceholder(func(n int) string {
+		return "$" + strconv.Itoa(n)
+	})
+}
+
+var _ Store = (*sqlStore)(nil)
+
+// NewSQLStore returns a new Store which keeps everything in a table of the given database.
+// The table is not created automatically and needs the following columns:
+//
+//	CREATE TABLE disgo_oauth2 (
+//	    id         VARCHAR(255) PRIMARY KEY,
+//	    data       BLOB NOT NULL, -- BYTEA in PostgreSQL
+//	    expires_at BIGINT NOT NULL -- unix milliseconds or 0 if it never expires
+//	)
+//
+// Expired rows are never read but only removed when the key is set again. Delete them periodically to keep the table small.
+func NewSQLStore(db *sql.DB, opts ...SQLStoreConfigOpt) Store {
+	config := DefaultSQLStoreConfig()
+	config.Apply(opts)
+
+	p := config.Placeholder
+	return &sqlStore{
+		db:        db,
+		getQuery:  "SELECT data, expires_at FROM " + config.Table + " WHERE id = " + p(1),
+		setQuery:  "INSERT INTO " + config.Table + " (id, data, expires_at) VALUES (" + p(1) + ", " + p(2) + ", " + p(3) + ")",
+		delQuery:  "DELETE FROM " + config.Table + " WHERE id = " + p(1),
+		delIfLive: "DELETE FROM " + config.Table + " WHERE id = " + p(1) + " AND (expires_at = 0 OR expires_at > " + p(2) + ")",
+	}
+}
+
+type sqlStore struct {
+	db        *sql.DB
+	getQuery  string
+	setQuery  string
+	delQuery  string
+	delIfLive string
+}
+
+func (s *sqlStore) Get(ctx context.Context, key string) ([]byte, error) {
+	var (
+		data      []byte
+		expiresAt int64
+	)
+	if err := s.db.QueryRowContext(ctx, s.getQuery, key).Scan(&data, &expiresAt); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrKeyNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if expiresAt != 0 && expiresAt <= time.Now().UnixMilli() {
+		return nil, ErrKeyNotFound
+	}
+	return data, nil
+}
+
+func (s *sqlStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixMilli()
+	}
+
+	// upserts are not portable between databases, so we replace the row in a transaction instead
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, s.delQuery, key); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, s.setQuery, key, value, expiresAt); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *sqlStore) Delete(ctx context.Context, key string) error {
+	result, err := s.db.ExecContext(ctx, s.delIfLive, key, time.Now().UnixMilli())
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrKeyNotFound
+	}
+	return nil
+}
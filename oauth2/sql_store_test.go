@@ -0,0 +1,119 @@
+package oauth2
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+)
+
+func init() {
+	sql.Register("oauth2test", &testDriver{tables: map[string]map[string]testRow{}})
+}
+
+// testDriver is a database/sql driver which only understands the queries of the SQL Store and keeps the rows in memory.
+type testDriver struct {
+	mu     sync.Mutex
+	tables map[string]map[string]testRow
+}
+
+type testRow struct {
+	data      []byte
+	expiresAt int64
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.tables[name]; !ok {
+		d.tables[name] = map[string]testRow{}
+	}
+	return &testConn{driver: d, rows: d.tables[name]}, nil
+}
+
+type testConn struct {
+	driver *testDriver
+	rows   map[string]testRow
+}
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{conn: c, query: query}, nil
+}
+
+func (c *testConn) Close() error { return nil }
+
+// Begin returns a transaction which applies all statements immediately, which is enough for the Store.
+func (c *testConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (c *testConn) Commit() error { return nil }
+
+func (c *testConn) Rollback() error { return nil }
+
+type testStmt struct {
+	conn  *testConn
+	query string
+}
+
+func (s *testStmt) Close() error { return nil }
+
+func (s *testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+
+	key := args[0].(string)
+	switch {
+	case strings.HasPrefix(s.query, "INSERT INTO "):
+		if _, ok := s.conn.rows[key]; ok {
+			return nil, errors.New("duplicate key")
+		}
+		s.conn.rows[key] = testRow{data: args[1].([]byte), expiresAt: args[2].(int64)}
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(s.query, "DELETE FROM "):
+		row, ok := s.conn.rows[key]
+		if !ok {
+			return driver.RowsAffected(0), nil
+		}
+		// delete only if live
+		if len(args) == 2 && row.expiresAt != 0 && row.expiresAt <= args[1].(int64) {
+			return driver.RowsAffected(0), nil
+		}
+		delete(s.conn.rows, key)
+		return driver.RowsAffected(1), nil
+	}
+	return nil, errors.New("unexpected query: " + s.query)
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(s.query, "SELECT data, expires_at FROM ") {
+		return nil, errors.New("unexpected query: " + s.query)
+	}
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	rows := &testRows{}
+	if row, ok := s.conn.rows[args[0].(string)]; ok {
+		rows.rows = append(rows.rows, row)
+	}
+	return rows, nil
+}
+
+type testRows struct {
+	rows []testRow
+}
+
+func (r *testRows) Columns() []string { return []string{"data", "expires_at"} }
+
+func (r *testRows) Close() error { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	dest[0], dest[1] = r.rows[0].data, r.rows[0].expiresAt
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -1,5 +1,11 @@
 package oauth2
 
+import (
+	"context"
+	"errors"
+	"time"
+)
+
 var (
 	_ StateController = (*stateControllerImpl)(nil)
 )
@@ -7,44 +13,59 @@ var (
 // StateController is responsible for generating, storing and validating states.
 type StateController interface {
 	// GenerateNewState generates a new random state to be used as a state.
-	GenerateNewState(redirectURI string) string
+	GenerateNewState(ctx context.Context, redirectURI string) (string, error)
 
-	// ConsumeState validates a state and returns the redirect url or nil if it is invalid.
-	ConsumeState(state string) string
+	// ConsumeState validates a state and returns the redirect url or ErrStateNotFound if it is invalid.
+	// A state can only be consumed once.
+	ConsumeState(ctx context.Context, state string) (string, error)
 }
 
-// NewStateController returns a new empty StateController.
+// NewStateController returns a new StateController which persists the states in the configured Store.
 func NewStateController(opts ...StateControllerConfigOpt) StateController {
 	config := DefaultStateControllerConfig()
 	config.Apply(opts)
 
-	states := newTTLMap(config.MaxTTL)
-	for state, url := range config.States {
-		states.put(state, url)
-	}
-
-	return &stateControllerImpl{
-		states:       states,
+	c := &stateControllerImpl{
+		store:        config.Store,
 		newStateFunc: config.NewStateFunc,
+		maxTTL:       config.MaxTTL,
 	}
+	for state, url := range config.States {
+		_ = c.store.Set(context.Background(), stateKey(state), []byte(url), c.maxTTL)
+	}
+	return c
 }
 
 type stateControllerImpl struct {
-	states       *ttlMap
+	store        Store
 	newStateFunc func() string
+	maxTTL       time.Duration
+}
+
+func stateKey(state string) string {
+	return "state:" + state
 }
 
-func (c *stateControllerImpl) GenerateNewState(redirectURI string) string {
+func (c *stateControllerImpl) GenerateNewState(ctx context.Context, redirectURI string) (string, error) {
 	state := c.newStateFunc()
-	c.states.put(state, redirectURI)
-	return state
+	if err := c.store.Set(ctx, stateKey(state), []byte(redirectURI), c.maxTTL); err != nil {
+		return "", err
+	}
+	return state, nil
 }
 
-func (c *stateControllerImpl) ConsumeState(state string) string {
-	uri := c.states.get(state)
-	if uri == "" {
-		return ""
+func (c *stateControllerImpl) ConsumeState(ctx context.Context, state string) (string, error) {
+	uri, err := c.store.Get(ctx, stateKey(state))
+	if errors.Is(err, ErrKeyNotFound) {
+		return "", ErrStateNotFound
+	} else if err != nil {
+		return "", err
+	}
+	// only whoever deletes the state may use it, so it can't be used twice at the same time
+	if err = c.store.Delete(ctx, stateKey(state)); errors.Is(err, ErrKeyNotFound) {
+		return "", ErrStateNotFound
+	} else if err != nil {
+		return "", err
 	}
-	c.states.delete(state)
-	return uri
+	return string(uri), nil
 }
@@ -17,6 +17,7 @@ func DefaultStateControllerConfig() *StateControllerConfig {
 
 // StateControllerConfig is the configuration for the StateController
 type StateControllerConfig struct {
+	Store        Store
 	States       map[string]string
 	NewStateFunc func() string
 	MaxTTL       time.Duration
@@ -30,9 +31,19 @@ func (c *StateControllerConfig) Apply(opts []StateControllerConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.Store == nil {
+		c.Store = NewMemoryStore()
+	}
+}
+
+// WithStateStore sets the Store the states are persisted in
+func WithStateStore(store Store) StateControllerConfigOpt {
+	return func(config *StateControllerConfig) {
+		config.Store = store
+	}
 }
 
-// WithStates loads states from an existing map
+// WithStates loads states from an existing map into the Store. Errors of the Store are ignored
 func WithStates(states map[string]string) StateControllerConfigOpt {
 	return func(config *StateControllerConfig) {
 		config.States = states
@@ -0,0 +1,101 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrKeyNotFound is returned by a Store when the key does not exist or has expired.
+var ErrKeyNotFound = errors.New("key could not be found")
+
+// Store is a key-value storage which the default SessionController & StateController persist their data in.
+// Sharing a persistent Store between multiple instances of your application lets them share Session(s) & states.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// Get returns the value of the given key or ErrKeyNotFound if it does not exist or has expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set sets the value of the given key. The key expires after the given ttl or never if it is 0.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete deletes the given key or returns ErrKeyNotFound if it does not exist.
+	// Only one of multiple concurrent calls for the same key may succeed, as states are consumed by deleting them.
+	Delete(ctx context.Context, key string) error
+}
+
+var _ Store = (*memoryStore)(nil)
+
+// NewMemoryStore returns a new Store which keeps everything in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		values: map[string]storeValue{},
+	}
+}
+
+type storeValue struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (v storeValue) expired(now time.Time) bool {
+	return !v.expiresAt.IsZero() && !now.Before(v.expiresAt)
+}
+
+type memoryStore struct {
+	values      map[string]storeValue
+	lastCleanup time.Time
+	mu          sync.Mutex
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok || v.expired(time.Now()) {
+		return nil, ErrKeyNotFound
+	}
+	return v.value, nil
+}
+
+func (s *memoryStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	s.cleanup(now)
+
+	v := storeValue{value: value}
+	if ttl > 0 {
+		v.expiresAt = now.Add(ttl)
+	}
+	s.values[key] = v
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[key]
+	if !ok {
+		return ErrKeyNotFound
+	}
+	delete(s.values, key)
+	if v.expired(time.Now()) {
+		return ErrKeyNotFound
+	}
+	return nil
+}
+
+// cleanup removes all expired values once per minute.
+func (s *memoryStore) cleanup(now time.Time) {
+	if now.Sub(s.lastCleanup) < time.Minute {
+		return
+	}
+	s.lastCleanup = now
+	for key, v := range s.values {
+		if v.expired(now) {
+			delete(s.values, key)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package oauth2
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	db, err := sql.Open("oauth2test", t.Name())
+	assert.NoError(t, err)
+	defer db.Close()
+
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+		"sql":    NewSQLStore(db, WithSQLDollarPlaceholders()),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, err := store.Get(ctx, "missing")
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+
+			assert.NoError(t, store.Set(ctx, "key", []byte("old"), 0))
+			assert.NoError(t, store.Set(ctx, "key", []byte("value"), 0))
+			value, err := store.Get(ctx, "key")
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("value"), value)
+
+			assert.NoError(t, store.Delete(ctx, "key"))
+			assert.ErrorIs(t, store.Delete(ctx, "key"), ErrKeyNotFound)
+
+			assert.NoError(t, store.Set(ctx, "expired", []byte("value"), time.Nanosecond))
+			time.Sleep(time.Millisecond)
+			_, err = store.Get(ctx, "expired")
+			assert.ErrorIs(t, err, ErrKeyNotFound)
+		})
+	}
+}
+
+func TestStateController_ConsumeState(t *testing.T) {
+	ctx := context.Background()
+	c := NewStateController()
+
+	state, err := c.GenerateNewState(ctx, "https://example.com")
+	assert.NoError(t, err)
+
+	uri, err := c.ConsumeState(ctx, state)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com", uri)
+
+	_, err = c.ConsumeState(ctx, state)
+	assert.ErrorIs(t, err, ErrStateNotFound)
+}
+
+func TestSessionController(t *testing.T) {
+	ctx := context.Background()
+	c := NewSessionController()
+
+	created, err := c.CreateSessionFromResponse(ctx, "id", discord.GrantTypeAuthorizationCode, discord.AccessTokenResponse{
+		AccessToken: "access",
+		Scope:       []discord.OAuth2Scope{discord.OAuth2ScopeIdentify},
+		ExpiresIn:   time.Hour,
+	})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), created.Expiration(), time.Minute)
+
+	session, err := c.GetSession(ctx, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "access", session.AccessToken())
+	assert.Equal(t, created.Scopes(), session.Scopes())
+	assert.Equal(t, discord.GrantTypeAuthorizationCode, session.GrantType())
+	assert.True(t, created.Expiration().Equal(session.Expiration()))
+
+	assert.NoError(t, c.DeleteSession(ctx, "id"))
+	_, err = c.GetSession(ctx, "id")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
@@ -0,0 +1,15 @@
+package oauth2
+
+import (
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/webhook"
+)
+
+// NewWebhookClient returns a webhook.Client for the webhook created when the discord.OAuth2ScopeWebhookIncoming was authorized.
+func NewWebhookClient(session Session, opts ...webhook.ConfigOpt) (webhook.Client, error) {
+	incomingWebhook := session.Webhook()
+	if incomingWebhook == nil {
+		return nil, ErrMissingOAuth2Scope(discord.OAuth2ScopeWebhookIncoming)
+	}
+	return webhook.New(incomingWebhook.ID(), incomingWebhook.Token, opts...), nil
+}
@@ -29,6 +29,9 @@ type Applications interface {
 
 	GetGuildCommandsPermissions(applicationID snowflake.ID, guildID snowflake.ID, opts ...RequestOpt) ([]discord.ApplicationCommandPermissions, error)
 	GetGuildCommandPermissions(applicationID snowflake.ID, guildID snowflake.ID, commandID snowflake.ID, opts ...RequestOpt) (*discord.ApplicationCommandPermissions, error)
+
+	GetApplicationRoleConnectionMetadata(applicationID snowflake.ID, opts ...RequestOpt) ([]discord.ApplicationRoleConnectionMetadata, error)
+	UpdateApplicationRoleConnectionMetadata(applicationID snowflake.ID, newRecords []discord.ApplicationRoleConnectionMetadata, opts ...RequestOpt) ([]discord.ApplicationRoleConnectionMetadata, error)
 }
 
 type applicationsImpl struct {
@@ -211,6 +214,26 @@ func (s *applicationsImpl) GetGuildCommandPermissions(applicationID snowflake.ID
 	return
 }
 
+func (s *applicationsImpl) GetApplicationRoleConnectionMetadata(applicationID snowflake.ID, opts ...RequestOpt) (records []discord.ApplicationRoleConnectionMetadata, err error) {
+	var compiledRoute *route.CompiledAPIRoute
+	compiledRoute, err = route.GetApplicationRoleConnectionMetadata.Compile(nil, applicationID)
+	if err != nil {
+		return
+	}
+	err = s.client.Do(compiledRoute, nil, &records, opts...)
+	return
+}
+
+func (s *applicationsImpl) UpdateApplicationRoleConnectionMetadata(applicationID snowflake.ID, newRecords []discord.ApplicationRoleConnectionMetadata, opts ...RequestOpt) (records []discord.ApplicationRoleConnectionMetadata, err error) {
+	var compiledRoute *route.CompiledAPIRoute
+	compiledRoute, err = route.UpdateApplicationRoleConnectionMetadata.Compile(nil, applicationID)
+	if err != nil {
+		return
+	}
+	err = s.client.Do(compiledRoute, newRecords, &records, opts...)
+	return
+}
+
 func unmarshalApplicationCommandsToApplicationCommands(unmarshalCommands []discord.UnmarshalApplicationCommand) []discord.ApplicationCommand {
 	commands := make([]discord.ApplicationCommand, len(unmarshalCommands))
 	for i := range unmarshalCommands {
@@ -22,11 +22,15 @@ type OAuth2 interface {
 	GetCurrentMember(bearerToken string, guildID snowflake.ID, opts ...RequestOpt) (*discord.Member, error)
 	GetCurrentUserGuilds(bearerToken string, before snowflake.ID, after snowflake.ID, limit int, opts ...RequestOpt) ([]discord.OAuth2Guild, error)
 	GetCurrentUserConnections(bearerToken string, opts ...RequestOpt) ([]discord.Connection, error)
+	GetCurrentUserApplicationRoleConnection(bearerToken string, applicationID snowflake.ID, opts ...RequestOpt) (*discord.ApplicationRoleConnection, error)
+	UpdateCurrentUserApplicationRoleConnection(bearerToken string, applicationID snowflake.ID, connectionUpdate discord.ApplicationRoleConnectionUpdate, opts ...RequestOpt) (*discord.ApplicationRoleConnection, error)
 
 	SetGuildCommandPermissions(bearerToken string, applicationID snowflake.ID, guildID snowflake.ID, commandID snowflake.ID, commandPermissions []discord.ApplicationCommandPermission, opts ...RequestOpt) (*discord.ApplicationCommandPermissions, error)
 
 	GetAccessToken(clientID snowflake.ID, clientSecret string, code string, redirectURI string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
 	RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	GetClientCredentialsAccessToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (*discord.AccessTokenResponse, error)
+	RevokeToken(clientID snowflake.ID, clientSecret string, token string, tokenTypeHint discord.TokenTypeHint, opts ...RequestOpt) error
 }
 
 type oAuth2Impl struct {
@@ -115,6 +119,28 @@ func (s *oAuth2Impl) GetCurrentUserConnections(bearerToken string, opts ...Reque
 	return
 }
 
+func (s *oAuth2Impl) GetCurrentUserApplicationRoleConnection(bearerToken string, applicationID snowflake.ID, opts ...RequestOpt) (connection *discord.ApplicationRoleConnection, err error) {
+	var compiledRoute *route.CompiledAPIRoute
+	compiledRoute, err = route.GetCurrentUserApplicationRoleConnection.Compile(nil, applicationID)
+	if err != nil {
+		return
+	}
+
+	err = s.client.Do(compiledRoute, nil, &connection, withBearerToken(bearerToken, opts)...)
+	return
+}
+
+func (s *oAuth2Impl) UpdateCurrentUserApplicationRoleConnection(bearerToken string, applicationID snowflake.ID, connectionUpdate discord.ApplicationRoleConnectionUpdate, opts ...RequestOpt) (connection *discord.ApplicationRoleConnection, err error) {
+	var compiledRoute *route.CompiledAPIRoute
+	compiledRoute, err = route.UpdateCurrentUserApplicationRoleConnection.Compile(nil, applicationID)
+	if err != nil {
+		return
+	}
+
+	err = s.client.Do(compiledRoute, connectionUpdate, &connection, withBearerToken(bearerToken, opts)...)
+	return
+}
+
 func (s *oAuth2Impl) SetGuildCommandPermissions(bearerToken string, applicationID snowflake.ID, guildID snowflake.ID, commandID snowflake.ID, commandPermissions []discord.ApplicationCommandPermission, opts ...RequestOpt) (commandPerms *discord.ApplicationCommandPermissions, err error) {
 	var compiledRoute *route.CompiledAPIRoute
 	compiledRoute, err = route.SetGuildCommandPermissions.Compile(nil, applicationID, guildID, commandID)
@@ -139,6 +165,10 @@ func (s *oAuth2Impl) exchangeAccessToken(clientID snowflake.ID, clientSecret str
 	case discord.GrantTypeRefreshToken:
 		values["refresh_token"] = []string{codeOrRefreshToken}
 	}
+	return s.doExchange(values, opts...)
+}
+
+func (s *oAuth2Impl) doExchange(values url.Values, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	var compiledRoute *route.CompiledAPIRoute
 	compiledRoute, err = route.Token.Compile(nil)
 	if err != nil {
@@ -156,3 +186,28 @@ func (s *oAuth2Impl) GetAccessToken(clientID snowflake.ID, clientSecret string,
 func (s *oAuth2Impl) RefreshAccessToken(clientID snowflake.ID, clientSecret string, refreshToken string, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
 	return s.exchangeAccessToken(clientID, clientSecret, discord.GrantTypeRefreshToken, refreshToken, "", opts...)
 }
+
+func (s *oAuth2Impl) GetClientCredentialsAccessToken(clientID snowflake.ID, clientSecret string, scopes []discord.OAuth2Scope, opts ...RequestOpt) (exchange *discord.AccessTokenResponse, err error) {
+	return s.doExchange(url.Values{
+		"client_id":     []string{clientID.String()},
+		"client_secret": []string{clientSecret},
+		"grant_type":    []string{discord.GrantTypeClientCredentials.String()},
+		"scope":         []string{discord.JoinScopes(scopes)},
+	}, opts...)
+}
+
+func (s *oAuth2Impl) RevokeToken(clientID snowflake.ID, clientSecret string, token string, tokenTypeHint discord.TokenTypeHint, opts ...RequestOpt) error {
+	values := url.Values{
+		"client_id":     []string{clientID.String()},
+		"client_secret": []string{clientSecret},
+		"token":         []string{token},
+	}
+	if tokenTypeHint != "" {
+		values["token_type_hint"] = []string{tokenTypeHint.String()}
+	}
+	compiledRoute, err := route.RevokeToken.Compile(nil)
+	if err != nil {
+		return err
+	}
+	return s.client.Do(compiledRoute, values, nil, opts...)
+}
@@ -22,6 +22,9 @@ type RequestConfig struct {
 	Ctx     context.Context
 	Checks  []Check
 	Delay   time.Duration
+
+	// UploadProgress is called while the request body is sent with the bytes sent so far and the total or -1 if it is unknown
+	UploadProgress func(sent int64, total int64)
 }
 
 // Check is a function which gets executed right before a request is made
@@ -61,6 +64,14 @@ func WithDelay(delay time.Duration) RequestOpt {
 	}
 }
 
+// WithUploadProgress sets a func which is called while the request body is sent with the bytes sent so far and the total or -1 if it is unknown.
+// This is useful to report the progress of uploading large files
+func WithUploadProgress(uploadProgress func(sent int64, total int64)) RequestOpt {
+	return func(config *RequestConfig) {
+		config.UploadProgress = uploadProgress
+	}
+}
+
 // WithReason adds a reason header to the request. Not all discord endpoints support this
 func WithReason(reason string) RequestOpt {
 	return func(config *RequestConfig) {
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/disgoorg/disgo/json"
@@ -68,14 +69,24 @@ func (c *clientImpl) RateLimiter() RateLimiter {
 
 func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody any, tries int, opts []RequestOpt) error {
 	var (
-		rqURL       = cRoute.URL()
-		rawRqBody   []byte
-		err         error
-		contentType string
+		rqURL         = c.url(cRoute)
+		rawRqBody     []byte
+		rqBodyReader  io.Reader
+		contentLength int64 = -1
+		err           error
+		contentType   string
 	)
 
 	if rqBody != nil {
 		switch v := rqBody.(type) {
+		case *discord.MultipartStream:
+			contentType = v.ContentType
+			contentLength = v.ContentLength
+			if rqBodyReader, err = v.Reader(); err != nil {
+				return fmt.Errorf("failed to read multipart body: %w", err)
+			}
+			c.Logger().Tracef("request to %s, body: streamed multipart body", rqURL)
+
 		case *discord.MultipartBuffer:
 			contentType = v.ContentType
 			rawRqBody = v.Buffer.Bytes()
@@ -90,13 +101,30 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 				return fmt.Errorf("failed to marshal request body: %w", err)
 			}
 		}
-		c.Logger().Tracef("request to %s, body: %s", rqURL, string(rawRqBody))
+		if rqBodyReader == nil {
+			c.Logger().Tracef("request to %s, body: %s", rqURL, string(rawRqBody))
+		}
+	}
+	if rqBodyReader == nil {
+		rqBodyReader = bytes.NewReader(rawRqBody)
 	}
 
-	rq, err := http.NewRequest(cRoute.APIRoute.Method().String(), rqURL, bytes.NewReader(rawRqBody))
+	rq, err := http.NewRequest(cRoute.APIRoute.Method().String(), rqURL, rqBodyReader)
 	if err != nil {
+		if closer, ok := rqBodyReader.(io.Closer); ok {
+			_ = closer.Close()
+		}
 		return err
 	}
+	if contentLength >= 0 {
+		rq.ContentLength = contentLength
+	}
+	// streamed bodies are written until the request body is closed, which the http.Client does for us once the request is made
+	closeBody := func() {
+		if rq.Body != nil {
+			_ = rq.Body.Close()
+		}
+	}
 
 	rq.Header.Set("User-Agent", c.config.UserAgent)
 	if contentType != "" {
@@ -116,6 +144,7 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 		defer timer.Stop()
 		select {
 		case <-config.Ctx.Done():
+			closeBody()
 			return config.Ctx.Err()
 		case <-timer.C:
 		}
@@ -124,18 +153,29 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 	// wait for rate limits
 	err = c.RateLimiter().WaitBucket(config.Ctx, cRoute)
 	if err != nil {
+		closeBody()
 		return fmt.Errorf("error locking bucket in rest client: %w", err)
 	}
-	rq = rq.WithContext(config.Ctx)
+	config.Request = config.Request.WithContext(config.Ctx)
+	rq = config.Request
 
 	for _, check := range config.Checks {
 		if !check() {
+			closeBody()
 			_ = c.RateLimiter().UnlockBucket(cRoute, nil)
 			return discord.ErrCheckFailed
 		}
 	}
 
-	rs, err := c.HTTPClient().Do(config.Request)
+	if config.UploadProgress != nil && rq.Body != nil {
+		total := rq.ContentLength
+		if total == 0 {
+			total = -1
+		}
+		rq.Body = &progressReader{ReadCloser: rq.Body, total: total, onProgress: config.UploadProgress}
+	}
+
+	rs, err := c.HTTPClient().Do(rq)
 	if err != nil {
 		_ = c.RateLimiter().UnlockBucket(cRoute, nil)
 		return fmt.Errorf("error doing request in rest client: %w", err)
@@ -175,6 +215,32 @@ func (c *clientImpl) retry(cRoute *route.CompiledAPIRoute, rqBody any, rsBody an
 	}
 }
 
+// url returns the URL of the given route.CompiledAPIRoute with route.API replaced by the configured URL. Custom routes are left untouched.
+func (c *clientImpl) url(cRoute *route.CompiledAPIRoute) string {
+	u := cRoute.URL()
+	if c.config.URL == "" || c.config.URL == route.API || !strings.HasPrefix(u, route.API) {
+		return u
+	}
+	return c.config.URL + strings.TrimPrefix(u, route.API)
+}
+
 func (c *clientImpl) Do(cRoute *route.CompiledAPIRoute, rqBody any, rsBody any, opts ...RequestOpt) error {
 	return c.retry(cRoute, rqBody, rsBody, 1, opts)
 }
+
+// progressReader reports how many bytes of the request body were read by the http.Client.
+type progressReader struct {
+	io.ReadCloser
+	read       int64
+	total      int64
+	onProgress func(sent int64, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.read += int64(n)
+		r.onProgress(r.read, r.total)
+	}
+	return n, err
+}
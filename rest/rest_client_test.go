@@ -0,0 +1,57 @@
+package rest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestClient_RetryMultipartStream(t *testing.T) {
+	var (
+		files []string
+		mu    sync.Mutex
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("files[0]")
+		require.NoError(t, err)
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+
+		mu.Lock()
+		files = append(files, string(content))
+		first := len(files) == 1
+		mu.Unlock()
+
+		if first {
+			w.Header().Set("X-RateLimit-Bucket", "bucket")
+			w.Header().Set("Retry-After", "0")
+			w.Header().Set("Via", "1.1 google")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "1", "channel_id": "2", "content": "content"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("MTIz.test.token", WithURL(server.URL), WithHTTPClient(server.Client()))
+	defer client.Close(context.Background())
+
+	// an io.MultiReader can't seek, so the file needs to be kept for the retry
+	message, err := New(client).CreateMessage(2, discord.MessageCreate{
+		Content: "content",
+		Files:   []*discord.File{discord.NewFile("file.txt", "", io.MultiReader(strings.NewReader("x")))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "content", message.Content)
+	assert.Equal(t, []string{"x", "x"}, files)
+}
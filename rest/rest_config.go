@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/disgoorg/log"
+
+	"github.com/disgoorg/disgo/rest/route"
 )
 
 // DefaultConfig is the configuration which is used by default
@@ -12,6 +14,7 @@ func DefaultConfig() *Config {
 	return &Config{
 		Logger:     log.Default(),
 		HTTPClient: &http.Client{Timeout: 20 * time.Second},
+		URL:        route.API,
 	}
 }
 
@@ -22,6 +25,7 @@ type Config struct {
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
 	UserAgent                 string
+	URL                       string
 }
 
 // ConfigOpt can be used to supply optional parameters to NewClient
@@ -71,3 +75,10 @@ func WithUserAgent(userAgent string) ConfigOpt {
 		config.UserAgent = userAgent
 	}
 }
+
+// WithURL sets the base URL requests to Discord's API are sent to instead of route.API, for example the URL of a proxy or a test server
+func WithURL(url string) ConfigOpt {
+	return func(config *Config) {
+		config.URL = url
+	}
+}
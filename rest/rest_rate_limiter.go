@@ -3,6 +3,7 @@ package rest
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/disgoorg/disgo/rest/route"
 	"github.com/disgoorg/log"
@@ -29,3 +30,11 @@ type RateLimiter interface {
 	// UnlockBucket unlocks the given bucket and calculates the rate limit for the next request
 	UnlockBucket(route *route.CompiledAPIRoute, rs *http.Response) error
 }
+
+// BucketInspector can optionally be implemented by a RateLimiter to report the state of its buckets.
+// It is used to balance requests between routes like multiple webhooks.
+type BucketInspector interface {
+	// BucketAvailableAt returns the time the bucket of the given route accepts new requests again & whether a request is currently in progress on it.
+	// A time in the past means the bucket is available now.
+	BucketAvailableAt(route *route.CompiledAPIRoute) (time.Time, bool)
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/sasha-s/go-csync"
 )
 
+var _ BucketInspector = (*rateLimiterImpl)(nil)
+
 // NewRateLimiter return a new default RateLimiter with the given RateLimiterConfigOpt(s).
 func NewRateLimiter(opts ...RateLimiterConfigOpt) RateLimiter {
 	config := DefaultRateLimiterConfig()
@@ -176,6 +178,22 @@ func (l *rateLimiterImpl) WaitBucket(ctx context.Context, route *route.CompiledA
 	return nil
 }
 
+func (l *rateLimiterImpl) BucketAvailableAt(route *route.CompiledAPIRoute) (time.Time, bool) {
+	until := l.global
+	b := l.getBucket(route, false)
+	if b == nil {
+		return until, false
+	}
+	if !b.mu.TryLock() {
+		return until, true
+	}
+	defer b.mu.Unlock()
+	if b.Remaining == 0 && b.Reset.After(until) {
+		until = b.Reset
+	}
+	return until, false
+}
+
 func (l *rateLimiterImpl) UnlockBucket(route *route.CompiledAPIRoute, rs *http.Response) error {
 	b := l.getBucket(route, false)
 	if b == nil {
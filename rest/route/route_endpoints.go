@@ -21,6 +21,7 @@ var (
 	GetAuthorizationInfo  = NewAPIRouteNoAuth(GET, "/oauth2/@me")
 	Authorize             = NewRoute("/oauth2/authorize", "client_id", "permissions", "redirect_uri", "response_type", "scope", "state", "guild_id", "disable_guild_select")
 	Token                 = NewAPIRouteNoAuth(POST, "/oauth2/token")
+	RevokeToken           = NewAPIRouteNoAuth(POST, "/oauth2/token/revoke")
 )
 
 // Users
@@ -34,6 +35,9 @@ var (
 	LeaveGuild                = NewAPIRoute(DELETE, "/users/@me/guilds/{guild.id}")
 	GetDMChannels             = NewAPIRoute(GET, "/users/@me/channels")
 	CreateDMChannel           = NewAPIRoute(POST, "/users/@me/channels")
+
+	GetCurrentUserApplicationRoleConnection    = NewAPIRouteNoAuth(GET, "/users/@me/applications/{application.id}/role-connection")
+	UpdateCurrentUserApplicationRoleConnection = NewAPIRouteNoAuth(PUT, "/users/@me/applications/{application.id}/role-connection")
 )
 
 // Guilds
@@ -172,7 +176,7 @@ var (
 
 // Messages
 var (
-	GetMessages        = NewAPIRoute(GET, "/channels/{channel.id}/messages")
+	GetMessages        = NewAPIRoute(GET, "/channels/{channel.id}/messages", "around", "before", "after", "limit")
 	GetMessage         = NewAPIRoute(GET, "/channels/{channel.id}/messages/{message.id}")
 	CreateMessage      = NewAPIRoute(POST, "/channels/{channel.id}/messages")
 	UpdateMessage      = NewAPIRoute(PATCH, "/channels/{channel.id}/messages/{message.id}")
@@ -260,6 +264,9 @@ var (
 	SetGuildCommandsPermissions = NewAPIRoute(PUT, "/applications/{application.id}/guilds/{guild.id}/commands/permissions")
 	SetGuildCommandPermissions  = NewAPIRoute(PUT, "/applications/{application.id}/guilds/{guild.id}/commands/{command.id}/permissions")
 
+	GetApplicationRoleConnectionMetadata    = NewAPIRoute(GET, "/applications/{application.id}/role-connections/metadata")
+	UpdateApplicationRoleConnectionMetadata = NewAPIRoute(PUT, "/applications/{application.id}/role-connections/metadata")
+
 	GetInteractionResponse    = NewAPIRouteNoAuth(GET, "/webhooks/{application.id}/{interaction.token}/messages/@original")
 	CreateInteractionResponse = NewAPIRouteNoAuth(POST, "/interactions/{interaction.id}/{interaction.token}/callback")
 	UpdateInteractionResponse = NewAPIRouteNoAuth(PATCH, "/webhooks/{application.id}/{interaction.token}/messages/@original")
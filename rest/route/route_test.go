@@ -20,6 +20,17 @@ func TestAPIRoute_Compile(t *testing.T) {
 	assert.Equal(t, API+"/channels/test1/messages/test2/reactions/test3/@me?wait=true", compiledRoute.URL())
 }
 
+func TestAPIRoute_CompileGetMessages(t *testing.T) {
+	compiledRoute, err := GetMessages.Compile(QueryValues{
+		"around": 1,
+		"before": 2,
+		"after":  3,
+		"limit":  50,
+	}, "test1")
+	assert.NoError(t, err)
+	assert.Equal(t, API+"/channels/test1/messages?after=3&around=1&before=2&limit=50", compiledRoute.URL())
+}
+
 func TestCDNRoute_Compile(t *testing.T) {
 	compiledRoute, err := CDNTestRoute.Compile(nil, PNG, 256, "test1")
 	assert.NoError(t, err)
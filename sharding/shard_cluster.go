@@ -0,0 +1,60 @@
+package sharding
+
+import (
+	"context"
+
+	"github.com/disgoorg/disgo/gateway"
+)
+
+// Forwarder sends gateway commands to shards which are managed by another cluster.
+// The receiving cluster should pass them to ShardManager.SendToShard.
+type Forwarder interface {
+	// Forward sends the command to the shard with the given ID of the cluster with the given ID.
+	Forward(ctx context.Context, clusterID int, shardID int, op gateway.Opcode, data gateway.MessageData) error
+}
+
+var _ Forwarder = (ForwarderFunc)(nil)
+
+// ForwarderFunc is a function which implements the Forwarder interface.
+type ForwarderFunc func(ctx context.Context, clusterID int, shardID int, op gateway.Opcode, data gateway.MessageData) error
+
+// Forward calls the ForwarderFunc.
+func (f ForwarderFunc) Forward(ctx context.Context, clusterID int, shardID int, op gateway.Opcode, data gateway.MessageData) error {
+	return f(ctx, clusterID, shardID, op, data)
+}
+
+// ClusterIDByShardID returns the cluster ID for the given shardID and shardsPerCluster. If shardsPerCluster is not positive, 0 is returned.
+func ClusterIDByShardID(shardID int, shardsPerCluster int) int {
+	if shardsPerCluster <= 0 {
+		return 0
+	}
+	return shardID / shardsPerCluster
+}
+
+// ClusterShardIDs returns the shard IDs of the given cluster for the given shardCount & shardsPerCluster.
+func ClusterShardIDs(clusterID int, shardCount int, shardsPerCluster int) []int {
+	var shardIDs []int
+	for shardID := clusterID * shardsPerCluster; shardID < (clusterID+1)*shardsPerCluster && shardID < shardCount; shardID++ {
+		shardIDs = append(shardIDs, shardID)
+	}
+	return shardIDs
+}
+
+// shardsPerCluster returns the configured shards per cluster or splits the given shardCount evenly between all clusters.
+func (c *Config) shardsPerCluster(shardCount int) int {
+	if c.ShardsPerCluster > 0 {
+		return c.ShardsPerCluster
+	}
+	if c.ClusterCount <= 1 {
+		return shardCount
+	}
+	return (shardCount + c.ClusterCount - 1) / c.ClusterCount
+}
+
+// shardIDs returns the shard IDs of this cluster for the given shardCount, which are all if no cluster is configured.
+func (c *Config) shardIDs(shardCount int) []int {
+	if c.ClusterCount == 0 {
+		return ClusterShardIDs(0, shardCount, shardCount)
+	}
+	return ClusterShardIDs(c.ClusterID, shardCount, c.shardsPerCluster(shardCount))
+}
@@ -15,7 +15,8 @@ type ShardManager interface {
 	Logger() log.Logger
 
 	// Open opens all configured shards.
-	Open(ctx context.Context)
+	// If a rest.Gateway is configured, the recommended shard count & max concurrency are fetched first.
+	Open(ctx context.Context) error
 	// Close closes all shards.
 	Close(ctx context.Context)
 
@@ -25,9 +26,31 @@ type ShardManager interface {
 	// CloseShard closes a specific shard.
 	CloseShard(ctx context.Context, shardID int)
 
+	// Reshard moves all shards to the given shard count without missing events.
+	// The new shards are opened next to the old ones and the old ones are only closed once all new shards received their guilds.
+	// Meanwhile, the events of the new shards are buffered and dispatched afterwards without the ones already dispatched by the old shards.
+	// If no shardIDs are given, all shards of the new shard count are opened.
+	// Use a context.Context with a deadline, as guilds which are unavailable due to an outage might never be received.
+	Reshard(ctx context.Context, shardCount int, shardIDs ...int) error
+
 	// ShardByGuildID returns the gateway.Gateway for the shard that contains the given guild.
 	ShardByGuildID(guildId snowflake.ID) gateway.Gateway
 
+	// IsLocalGuild returns whether the shard of the given guild is managed by this ShardManager.
+	IsLocalGuild(guildID snowflake.ID) bool
+
+	// ClusterIDByGuildID returns the ID of the cluster which runs the shard of the given guild.
+	// If the shard count is not known yet, the ID of this cluster is returned.
+	ClusterIDByGuildID(guildID snowflake.ID) int
+
+	// SendToGuild sends a gateway command to the shard of the given guild.
+	// If the shard is run by another cluster, the command is passed to the configured Forwarder.
+	SendToGuild(ctx context.Context, guildID snowflake.ID, op gateway.Opcode, data gateway.MessageData) error
+
+	// SendToShard sends a gateway command to the shard with the given ID.
+	// If the shard is run by another cluster, the command is passed to the configured Forwarder.
+	SendToShard(ctx context.Context, shardID int, op gateway.Opcode, data gateway.MessageData) error
+
 	// Shard returns the gateway.Gateway for the given shard ID.
 	Shard(shardID int) gateway.Gateway
 
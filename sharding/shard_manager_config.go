@@ -2,7 +2,9 @@ package sharding
 
 import (
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
 )
 
 // DefaultConfig returns a Config with sensible defaults.
@@ -23,8 +25,24 @@ type Config struct {
 	AutoScaling               bool
 	GatewayCreateFunc         gateway.CreateFunc
 	GatewayConfigOpts         []gateway.ConfigOpt
+	StatusChangeHandlerFunc   gateway.StatusChangeHandlerFunc
+	GatewayRest               rest.Gateway
 	RateLimiter               RateLimiter
 	RateRateLimiterConfigOpts []RateLimiterConfigOpt
+
+	// ClusterID is the ID of the cluster this ShardManager runs, when the shards are split between multiple processes.
+	ClusterID int
+	// ClusterCount is the total number of clusters. Clustering is disabled if it is 0.
+	ClusterCount int
+	// ShardsPerCluster is the number of shards each cluster runs. By default, the shards are split evenly between all clusters.
+	ShardsPerCluster int
+	// Forwarder sends gateway commands for shards of other clusters.
+	Forwarder Forwarder
+
+	// GuildCachedFunc returns whether the guild was already dispatched before, e.g. because it is cached.
+	// While resharding, the GUILD_CREATE events of the new shards are only dispatched for guilds which are neither cached nor dispatched by an old shard.
+	// By default, all guilds are treated as cached.
+	GuildCachedFunc func(guildID snowflake.ID) bool
 }
 
 // ConfigOpt is a type alias for a function that takes a Config and is used to configure your Server.
@@ -35,7 +53,14 @@ func (c *Config) Apply(opts []ConfigOpt) {
 	for _, opt := range opts {
 		opt(c)
 	}
-	if c.RateLimiter == nil {
+	if len(c.ShardIDs) == 0 && c.ClusterCount > 0 && c.ShardCount > 0 {
+		c.ShardIDs = map[int]struct{}{}
+		for _, shardID := range c.shardIDs(c.ShardCount) {
+			c.ShardIDs[shardID] = struct{}{}
+		}
+	}
+	// the default RateLimiter is created on Open when the max concurrency is fetched
+	if c.RateLimiter == nil && c.GatewayRest == nil {
 		c.RateLimiter = NewRateLimiter(c.RateRateLimiterConfigOpts...)
 	}
 }
@@ -59,6 +84,17 @@ func WithShardIDs(shardIDs ...int) ConfigOpt {
 	}
 }
 
+// WithGatewayRest lets the ShardManager fetch the recommended shard count, the max concurrency & the gateway url from /gateway/bot when opening the shards.
+// Opening single shards & resharding reuse the result until the session start limit resets.
+// Shard IDs, shard count & RateLimiter which are configured manually are kept.
+// Opening fails with discord.ErrSessionStartLimit if Discord does not allow to start enough sessions anymore.
+// This is only checked if the shards are created by gateway.New.
+func WithGatewayRest(gatewayRest rest.Gateway) ConfigOpt {
+	return func(config *Config) {
+		config.GatewayRest = gatewayRest
+	}
+}
+
 // WithShardCount sets the shard count of the ShardManager.
 func WithShardCount(shardCount int) ConfigOpt {
 	return func(config *Config) {
@@ -95,6 +131,13 @@ func WithGatewayConfigOpts(opts ...gateway.ConfigOpt) ConfigOpt {
 	}
 }
 
+// WithStatusChangeHandler sets the gateway.StatusChangeHandlerFunc which is called when the gateway.Status of any shard changes.
+func WithStatusChangeHandler(statusChangeHandlerFunc gateway.StatusChangeHandlerFunc) ConfigOpt {
+	return func(config *Config) {
+		config.StatusChangeHandlerFunc = statusChangeHandlerFunc
+	}
+}
+
 // WithRateLimiter lets you inject your own srate.RateLimiter into the ShardManager.
 func WithRateLimiter(rateLimiter RateLimiter) ConfigOpt {
 	return func(config *Config) {
@@ -108,3 +151,34 @@ func WithRateRateLimiterConfigOpt(opts ...RateLimiterConfigOpt) ConfigOpt {
 		config.RateRateLimiterConfigOpts = append(config.RateRateLimiterConfigOpts, opts...)
 	}
 }
+
+// WithCluster sets the ID of the cluster this ShardManager runs and the total number of clusters.
+// Unless shard IDs are set, the ShardManager only manages the shards of this cluster.
+func WithCluster(clusterID int, clusterCount int) ConfigOpt {
+	return func(config *Config) {
+		config.ClusterID = clusterID
+		config.ClusterCount = clusterCount
+	}
+}
+
+// WithShardsPerCluster sets the number of shards each cluster runs.
+func WithShardsPerCluster(shardsPerCluster int) ConfigOpt {
+	return func(config *Config) {
+		config.ShardsPerCluster = shardsPerCluster
+	}
+}
+
+// WithGuildCachedFunc sets the func which returns whether the guild was already dispatched before, e.g. because it is cached.
+// It decides which GUILD_CREATE events of the new shards are dispatched while resharding.
+func WithGuildCachedFunc(guildCachedFunc func(guildID snowflake.ID) bool) ConfigOpt {
+	return func(config *Config) {
+		config.GuildCachedFunc = guildCachedFunc
+	}
+}
+
+// WithForwarder sets the Forwarder which sends gateway commands for guilds & shards of other clusters.
+func WithForwarder(forwarder Forwarder) ConfigOpt {
+	return func(config *Config) {
+		config.Forwarder = forwarder
+	}
+}
@@ -2,9 +2,14 @@ package sharding
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
+	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/log"
 	"github.com/disgoorg/snowflake/v2"
 	"github.com/gorilla/websocket"
@@ -22,6 +27,8 @@ func New(token string, eventHandlerFunc gateway.EventHandlerFunc, opts ...Config
 		token:            token,
 		eventHandlerFunc: eventHandlerFunc,
 		config:           *config,
+		// only shards created by gateway.New identify with Discord, others like relays don't start sessions
+		checkSessionStarts: reflect.ValueOf(config.GatewayCreateFunc).Pointer() == reflect.ValueOf(gateway.New).Pointer(),
 	}
 }
 
@@ -32,12 +39,109 @@ type shardManagerImpl struct {
 	token            string
 	eventHandlerFunc gateway.EventHandlerFunc
 	config           Config
+	gatewayURL       string
+
+	// checkSessionStarts is whether opening shards is checked against the discord.SessionStartLimit.
+	checkSessionStarts bool
+	// sessionStartLimit is the last discord.SessionStartLimit fetched from /gateway/bot minus the sessions started since.
+	// It is used until sessionStartLimitResetAt.
+	sessionStartLimit        *discord.SessionStartLimit
+	sessionStartLimitResetAt time.Time
+
+	// reshardLock prevents multiple reshards at the same time
+	reshardLock sync.Mutex
+	reshard     *reshard
+	reshardMu   sync.Mutex
 }
 
 func (m *shardManagerImpl) Logger() log.Logger {
 	return m.config.Logger
 }
 
+// gatewayConfigOpts returns the gateway.ConfigOpt(s) for the given shard.
+// The configured ones are copied, as the shards are created concurrently.
+func (m *shardManagerImpl) gatewayConfigOpts(shardID int, shardCount int) []gateway.ConfigOpt {
+	opts := make([]gateway.ConfigOpt, 0, len(m.config.GatewayConfigOpts)+5)
+	if m.gatewayURL != "" {
+		opts = append(opts, gateway.WithURL(m.gatewayURL))
+	}
+	opts = append(opts, gateway.WithIdentifyRateLimiter(m.config.RateLimiter))
+	opts = append(opts, m.config.GatewayConfigOpts...)
+	opts = append(opts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))
+	if m.config.StatusChangeHandlerFunc != nil {
+		opts = append(opts, gateway.WithStatusChangeHandler(m.config.StatusChangeHandlerFunc))
+	}
+	return opts
+}
+
+// handleEvent passes the events of all shards to the gateway.EventHandlerFunc and remembers them while resharding.
+func (m *shardManagerImpl) handleEvent(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+	m.reshardMu.Lock()
+	r := m.reshard
+	m.reshardMu.Unlock()
+	if r != nil {
+		event = r.dispatched(eventType, event)
+	}
+	m.eventHandlerFunc(eventType, sequenceNumber, shardID, event)
+}
+
+// fetchGatewayBot applies the recommended shard count, max concurrency & gateway url from /gateway/bot if a rest.Gateway is configured and remembers the discord.SessionStartLimit.
+// It must be called while holding shardsMu.
+func (m *shardManagerImpl) fetchGatewayBot(ctx context.Context) error {
+	if m.config.GatewayRest == nil {
+		return nil
+	}
+	gatewayBot, err := m.config.GatewayRest.GetGatewayBot(rest.WithCtx(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get gateway bot: %w", err)
+	}
+	limit := gatewayBot.SessionStartLimit
+	m.sessionStartLimit = &limit
+	m.sessionStartLimitResetAt = time.Now().Add(time.Duration(limit.ResetAfter) * time.Millisecond)
+	m.Logger().Infof("%d of %d session starts remaining, resets at %s", limit.Remaining, limit.Total, m.sessionStartLimitResetAt.Format(time.RFC3339))
+
+	m.gatewayURL = gatewayBot.URL
+	if m.config.ShardCount == 0 {
+		m.config.ShardCount = gatewayBot.Shards
+	}
+	if len(m.config.ShardIDs) == 0 {
+		m.config.ShardIDs = make(map[int]struct{}, m.config.ShardCount)
+		for _, shardID := range m.config.shardIDs(m.config.ShardCount) {
+			m.config.ShardIDs[shardID] = struct{}{}
+		}
+	}
+	if m.config.RateLimiter == nil {
+		m.config.RateLimiter = NewRateLimiter(append([]RateLimiterConfigOpt{WithMaxConcurrency(limit.MaxConcurrency)}, m.config.RateRateLimiterConfigOpts...)...)
+	}
+	return nil
+}
+
+// startSessions fetches /gateway/bot if it was not fetched yet or the discord.SessionStartLimit was reset since and reserves the given number of sessions.
+// It returns discord.ErrSessionStartLimit if not enough sessions are remaining.
+// It must be called while holding shardsMu.
+func (m *shardManagerImpl) startSessions(ctx context.Context, sessions int) error {
+	if m.sessionStartLimit == nil || !time.Now().Before(m.sessionStartLimitResetAt) {
+		if err := m.fetchGatewayBot(ctx); err != nil {
+			return err
+		}
+	}
+	return m.reserveSessions(sessions)
+}
+
+// reserveSessions checks the given number of sessions against the discord.SessionStartLimit and subtracts them from the remaining ones.
+// It must be called while holding shardsMu.
+func (m *shardManagerImpl) reserveSessions(sessions int) error {
+	limit := m.sessionStartLimit
+	if !m.checkSessionStarts || limit == nil {
+		return nil
+	}
+	if limit.Remaining < sessions {
+		return fmt.Errorf("%w: %d sessions need to be started but only %d of %d are remaining until %s", discord.ErrSessionStartLimit, sessions, limit.Remaining, limit.Total, m.sessionStartLimitResetAt.Format(time.RFC3339))
+	}
+	limit.Remaining -= sessions
+	return nil
+}
+
 func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 	if closeError, ok := err.(*websocket.CloseError); !m.config.AutoScaling || !ok || gateway.CloseEventCode(closeError.Code) != gateway.CloseEventCodeShardingRequired {
 		return
@@ -71,13 +175,7 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := m.config.RateLimiter.WaitBucket(context.TODO(), shardID); err != nil {
-				m.Logger().Errorf("failed to wait shard bucket %d: %s", shardID, err)
-				return
-			}
-			defer m.config.RateLimiter.UnlockBucket(shardID)
-
-			newShard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(newShardCount))...)
+			newShard := m.config.GatewayCreateFunc(m.token, m.handleEvent, m.closeHandler, m.gatewayConfigOpts(shardID, newShardCount)...)
 			m.shards[shardID] = newShard
 			if err := newShard.Open(context.TODO()); err != nil {
 				m.Logger().Errorf("failed to re shard %d, error: %s", shardID, err)
@@ -88,35 +186,44 @@ func (m *shardManagerImpl) closeHandler(shard gateway.Gateway, err error) {
 	m.Logger().Debugf("re-sharded shard %d into newShards: %d, newShardCount: %d", shard.ShardID(), newShardIDs, newShardCount)
 }
 
-func (m *shardManagerImpl) Open(ctx context.Context) {
-	m.Logger().Debugf("opening %+v shards...", m.config.ShardIDs)
-	var wg sync.WaitGroup
-
+func (m *shardManagerImpl) Open(ctx context.Context) error {
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
+
+	if err := m.fetchGatewayBot(ctx); err != nil {
+		return err
+	}
+	var sessions int
+	for shardID := range m.config.ShardIDs {
+		if _, ok := m.shards[shardID]; !ok {
+			sessions++
+		}
+	}
+	if err := m.reserveSessions(sessions); err != nil {
+		return err
+	}
+
+	m.Logger().Debugf("opening %+v shards...", m.config.ShardIDs)
+	var wg sync.WaitGroup
 	for shardInt := range m.config.ShardIDs {
 		shardID := shardInt
 		if _, ok := m.shards[shardID]; ok {
 			continue
 		}
 
+		shard := m.config.GatewayCreateFunc(m.token, m.handleEvent, m.closeHandler, m.gatewayConfigOpts(shardID, m.config.ShardCount)...)
+		m.shards[shardID] = shard
+
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			if err := m.config.RateLimiter.WaitBucket(ctx, shardID); err != nil {
-				m.Logger().Errorf("failed to wait shard bucket %d: %s", shardID, err)
-				return
-			}
-			defer m.config.RateLimiter.UnlockBucket(shardID)
-
-			shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(m.config.ShardCount))...)
-			m.shards[shardID] = shard
 			if err := shard.Open(ctx); err != nil {
 				m.Logger().Errorf("failed to open shard %d: %s", shardID, err)
 			}
 		}()
 	}
 	wg.Wait()
+	return nil
 }
 
 func (m *shardManagerImpl) Close(ctx context.Context) {
@@ -138,17 +245,20 @@ func (m *shardManagerImpl) Close(ctx context.Context) {
 }
 
 func (m *shardManagerImpl) OpenShard(ctx context.Context, shardID int) error {
-	return m.openShard(ctx, shardID, m.config.ShardCount)
+	m.shardsMu.Lock()
+	err := m.startSessions(ctx, 1)
+	shardCount := m.config.ShardCount
+	m.shardsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	return m.openShard(ctx, shardID, shardCount)
 }
 
 func (m *shardManagerImpl) openShard(ctx context.Context, shardID int, shardCount int) error {
 	m.Logger().Debugf("opening shard %d...", shardID)
 
-	if err := m.config.RateLimiter.WaitBucket(ctx, shardID); err != nil {
-		return err
-	}
-	defer m.config.RateLimiter.UnlockBucket(shardID)
-	shard := m.config.GatewayCreateFunc(m.token, m.eventHandlerFunc, m.closeHandler, append(m.config.GatewayConfigOpts, gateway.WithShardID(shardID), gateway.WithShardCount(shardCount))...)
+	shard := m.config.GatewayCreateFunc(m.token, m.handleEvent, m.closeHandler, m.gatewayConfigOpts(shardID, shardCount)...)
 
 	m.shardsMu.Lock()
 	defer m.shardsMu.Unlock()
@@ -168,14 +278,180 @@ func (m *shardManagerImpl) CloseShard(ctx context.Context, shardID int) {
 	}
 }
 
+func (m *shardManagerImpl) Reshard(ctx context.Context, shardCount int, shardIDs ...int) error {
+	m.reshardLock.Lock()
+	defer m.reshardLock.Unlock()
+
+	if len(shardIDs) == 0 {
+		shardIDs = m.config.shardIDs(shardCount)
+	}
+
+	m.shardsMu.Lock()
+	err := m.startSessions(ctx, len(shardIDs))
+	m.shardsMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	m.Logger().Infof("resharding to %d shards %v...", shardCount, shardIDs)
+	r := newReshard(m.handleEvent, m.config.GuildCachedFunc, shardIDs)
+	m.reshardMu.Lock()
+	m.reshard = r
+	m.reshardMu.Unlock()
+
+	newShards := make(map[int]gateway.Gateway, len(shardIDs))
+	for _, shardID := range shardIDs {
+		newShards[shardID] = m.config.GatewayCreateFunc(m.token, r.eventHandlerFunc(shardID), m.closeHandler, m.gatewayConfigOpts(shardID, shardCount)...)
+	}
+
+	if err = m.openReshard(ctx, r, newShards); err != nil {
+		m.reshardMu.Lock()
+		m.reshard = nil
+		m.reshardMu.Unlock()
+		closeShards(ctx, newShards)
+		return err
+	}
+
+	// the new shards are ready, so we can replace the old ones
+	m.shardsMu.Lock()
+	oldShards := m.shards
+	m.shards = newShards
+	m.config.ShardCount = shardCount
+	m.config.ShardIDs = make(map[int]struct{}, len(shardIDs))
+	for _, shardID := range shardIDs {
+		m.config.ShardIDs[shardID] = struct{}{}
+	}
+	m.shardsMu.Unlock()
+
+	closeShards(ctx, oldShards)
+	m.reshardMu.Lock()
+	m.reshard = nil
+	m.reshardMu.Unlock()
+	r.flush()
+
+	m.Logger().Infof("resharded to %d shards", shardCount)
+	return nil
+}
+
+// openReshard opens the new shards and waits until all of them received their guilds.
+func (m *shardManagerImpl) openReshard(ctx context.Context, r *reshard, shards map[int]gateway.Gateway) error {
+	var (
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		openErr error
+	)
+	for shardID := range shards {
+		shardID := shardID
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := shards[shardID].Open(ctx); err != nil {
+				errOnce.Do(func() {
+					openErr = fmt.Errorf("failed to open shard %d: %w", shardID, err)
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if openErr != nil {
+		return openErr
+	}
+
+	for i := 0; i < len(shards); i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-r.ready:
+		}
+	}
+	return nil
+}
+
+// closeShards closes the given shards concurrently.
+func closeShards(ctx context.Context, shards map[int]gateway.Gateway) {
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		shard := shard
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			shard.Close(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
 func (m *shardManagerImpl) ShardByGuildID(guildId snowflake.ID) gateway.Gateway {
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+	shardID, ok := m.shardIDByGuild(guildId)
+	if !ok {
+		return nil
+	}
+	return m.shards[shardID]
+}
+
+// shardIDByGuild returns the shard ID for the given guild and whether the shard is managed by this ShardManager.
+// Shards which were not split by AutoScaling yet keep their guilds, so the shard IDs of the smaller shard counts are checked as well.
+// It must be called while holding shardsMu.
+func (m *shardManagerImpl) shardIDByGuild(guildID snowflake.ID) (int, bool) {
+	for shardCount := m.config.ShardCount; shardCount > 0; shardCount /= m.config.ShardSplitCount {
+		shardID := ShardIDByGuild(guildID, shardCount)
+		if _, ok := m.config.ShardIDs[shardID]; ok {
+			return shardID, true
+		}
+		if !m.config.AutoScaling || m.config.ShardSplitCount < 2 {
+			break
+		}
+	}
+	if m.config.ShardCount == 0 {
+		return 0, false
+	}
+	return ShardIDByGuild(guildID, m.config.ShardCount), false
+}
+
+func (m *shardManagerImpl) IsLocalGuild(guildID snowflake.ID) bool {
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+	_, ok := m.shardIDByGuild(guildID)
+	return ok
+}
+
+func (m *shardManagerImpl) ClusterIDByGuildID(guildID snowflake.ID) int {
+	m.shardsMu.Lock()
+	defer m.shardsMu.Unlock()
+	shardID, ok := m.shardIDByGuild(guildID)
+	if ok || m.config.ClusterCount == 0 || m.config.ShardCount == 0 {
+		return m.config.ClusterID
+	}
+	return ClusterIDByShardID(shardID, m.config.shardsPerCluster(m.config.ShardCount))
+}
+
+func (m *shardManagerImpl) SendToGuild(ctx context.Context, guildID snowflake.ID, op gateway.Opcode, data gateway.MessageData) error {
+	m.shardsMu.Lock()
+	shardID, ok := m.shardIDByGuild(guildID)
+	m.shardsMu.Unlock()
+	if !ok && m.config.ShardCount == 0 {
+		return discord.ErrShardNotFound
+	}
+	return m.SendToShard(ctx, shardID, op, data)
+}
+
+func (m *shardManagerImpl) SendToShard(ctx context.Context, shardID int, op gateway.Opcode, data gateway.MessageData) error {
+	m.shardsMu.Lock()
+	shard := m.shards[shardID]
+	_, local := m.config.ShardIDs[shardID]
 	shardCount := m.config.ShardCount
-	var shard gateway.Gateway
-	for shard == nil || shardCount != 0 {
-		shard = m.Shard(ShardIDByGuild(guildId, shardCount))
-		shardCount /= m.config.ShardSplitCount
+	m.shardsMu.Unlock()
+	if shard != nil {
+		return shard.Send(ctx, op, data)
+	}
+	if local || m.config.Forwarder == nil || m.config.ClusterCount == 0 || shardID >= shardCount {
+		return discord.ErrShardNotFound
 	}
-	return shard
+	clusterID := ClusterIDByShardID(shardID, m.config.shardsPerCluster(shardCount))
+	m.Logger().Debugf("forwarding opcode %d for shard %d to cluster %d", op, shardID, clusterID)
+	return m.config.Forwarder.Forward(ctx, clusterID, shardID, op, data)
 }
 
 func (m *shardManagerImpl) Shard(shardID int) gateway.Gateway {
@@ -191,5 +467,5 @@ func (m *shardManagerImpl) Shards() map[int]gateway.Gateway {
 	for shardID, shard := range m.shards {
 		shards[shardID] = shard
 	}
-	return m.shards
+	return shards
 }
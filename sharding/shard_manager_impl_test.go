@@ -0,0 +1,269 @@
+package sharding
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/rest"
+)
+
+type fakeGatewayRest struct {
+	rest.Gateway
+	gatewayBot discord.GatewayBot
+	calls      int
+}
+
+func (r *fakeGatewayRest) GetGatewayBot(_ ...rest.RequestOpt) (*discord.GatewayBot, error) {
+	r.calls++
+	gatewayBot := r.gatewayBot
+	return &gatewayBot, nil
+}
+
+type fakeGateway struct {
+	gateway.Gateway
+	config           gateway.Config
+	eventHandlerFunc gateway.EventHandlerFunc
+	closed           bool
+	sent             []gateway.Opcode
+}
+
+func (g *fakeGateway) Open(_ context.Context) error {
+	return nil
+}
+
+func (g *fakeGateway) Close(_ context.Context) {
+	g.closed = true
+}
+
+func (g *fakeGateway) Send(_ context.Context, op gateway.Opcode, _ gateway.MessageData) error {
+	g.sent = append(g.sent, op)
+	return nil
+}
+
+func (g *fakeGateway) dispatch(eventType gateway.EventType, sequenceNumber int, event gateway.EventData) {
+	g.eventHandlerFunc(eventType, sequenceNumber, g.config.ShardID, event)
+}
+
+func newFakeGateway(_ string, eventHandlerFunc gateway.EventHandlerFunc, _ gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+	config := gateway.DefaultConfig()
+	config.Apply(opts)
+	return &fakeGateway{config: *config, eventHandlerFunc: eventHandlerFunc}
+}
+
+func TestShardManager_GatewayRest(t *testing.T) {
+	gatewayRest := &fakeGatewayRest{gatewayBot: discord.GatewayBot{
+		URL:    "wss://example.com",
+		Shards: 3,
+		SessionStartLimit: discord.SessionStartLimit{
+			Total:          1000,
+			Remaining:      2,
+			MaxConcurrency: 1,
+		},
+	}}
+	m := New("", nil, WithGatewayRest(gatewayRest), WithGatewayCreateFunc(newFakeGateway))
+	// the fake shards don't identify with Discord, so the discord.SessionStartLimit is only checked when forced
+	m.(*shardManagerImpl).checkSessionStarts = true
+	assert.ErrorIs(t, m.Open(context.Background()), discord.ErrSessionStartLimit)
+
+	gatewayRest.gatewayBot.SessionStartLimit.Remaining = 3
+	assert.NoError(t, m.Open(context.Background()))
+
+	shards := m.Shards()
+	assert.Len(t, shards, 3)
+	for shardID, shard := range shards {
+		config := shard.(*fakeGateway).config
+		assert.Equal(t, shardID, config.ShardID)
+		assert.Equal(t, 3, config.ShardCount)
+		assert.Equal(t, "wss://example.com", config.URL)
+		assert.NotNil(t, config.IdentifyRateLimiter)
+	}
+}
+
+func TestShardManager_SessionStartLimitCached(t *testing.T) {
+	gatewayRest := &fakeGatewayRest{gatewayBot: discord.GatewayBot{
+		URL:    "wss://example.com",
+		Shards: 1,
+		SessionStartLimit: discord.SessionStartLimit{
+			Total:          1000,
+			Remaining:      2,
+			ResetAfter:     int(time.Hour.Milliseconds()),
+			MaxConcurrency: 1,
+		},
+	}}
+	m := New("", nil, WithGatewayRest(gatewayRest), WithGatewayCreateFunc(newFakeGateway))
+	m.(*shardManagerImpl).checkSessionStarts = true
+
+	// /gateway/bot is only fetched once until the limit resets & the started sessions are counted locally
+	assert.NoError(t, m.OpenShard(context.Background(), 0))
+	assert.NoError(t, m.OpenShard(context.Background(), 1))
+	assert.ErrorIs(t, m.OpenShard(context.Background(), 2), discord.ErrSessionStartLimit)
+	assert.Equal(t, 1, gatewayRest.calls)
+}
+
+func TestShardManager_SessionStartLimitSkipped(t *testing.T) {
+	gatewayRest := &fakeGatewayRest{gatewayBot: discord.GatewayBot{
+		URL:               "wss://example.com",
+		Shards:            3,
+		SessionStartLimit: discord.SessionStartLimit{Total: 1000, MaxConcurrency: 1},
+	}}
+	// shards not created by gateway.New don't start sessions, so no session starts remaining doesn't prevent opening them
+	m := New("", nil, WithGatewayRest(gatewayRest), WithGatewayCreateFunc(newFakeGateway))
+	assert.NoError(t, m.Open(context.Background()))
+	assert.Len(t, m.Shards(), 3)
+
+	assert.True(t, New("", nil).(*shardManagerImpl).checkSessionStarts)
+}
+
+func TestShardManager_Reshard(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		dispatched []string
+		created    = make(chan *fakeGateway, 2)
+	)
+	eventHandlerFunc := func(eventType gateway.EventType, _ int, shardID int, event gateway.EventData) {
+		mu.Lock()
+		defer mu.Unlock()
+		if e, ok := event.(gateway.EventMessageCreate); ok {
+			dispatched = append(dispatched, e.Content)
+			return
+		}
+		dispatched = append(dispatched, string(eventType))
+	}
+	createFunc := func(token string, eventHandlerFunc gateway.EventHandlerFunc, closeHandlerFunc gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+		shard := newFakeGateway(token, eventHandlerFunc, closeHandlerFunc, opts...).(*fakeGateway)
+		if shard.config.ShardCount == 2 {
+			created <- shard
+		}
+		return shard
+	}
+
+	m := New("", eventHandlerFunc, WithShardIDs(0), WithShardCount(1), WithGatewayCreateFunc(createFunc))
+	assert.NoError(t, m.Open(context.Background()))
+	oldShard := m.Shard(0).(*fakeGateway)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- m.Reshard(ctx, 2)
+	}()
+
+	newShards := map[int]*fakeGateway{}
+	for i := 0; i < 2; i++ {
+		shard := <-created
+		newShards[shard.config.ShardID] = shard
+	}
+
+	oldShard.dispatch(gateway.EventTypeMessageCreate, 10, gateway.EventMessageCreate{Message: discord.Message{Content: "both"}})
+	newShards[0].dispatch(gateway.EventTypeReady, 1, gateway.EventReady{Guilds: []discord.UnavailableGuild{{ID: 1}}})
+	newShards[0].dispatch(gateway.EventTypeMessageCreate, 2, gateway.EventMessageCreate{Message: discord.Message{Content: "both"}})
+	newShards[0].dispatch(gateway.EventTypeMessageCreate, 3, gateway.EventMessageCreate{Message: discord.Message{Content: "new"}})
+	newShards[1].dispatch(gateway.EventTypeReady, 1, gateway.EventReady{})
+	newShards[0].dispatch(gateway.EventTypeGuildCreate, 4, gateway.EventGuildCreate{GatewayGuild: discord.GatewayGuild{RestGuild: discord.RestGuild{Guild: discord.Guild{ID: snowflake.ID(1)}}}})
+
+	assert.NoError(t, <-done)
+	assert.True(t, oldShard.closed)
+	assert.Len(t, m.Shards(), 2)
+	assert.Equal(t, []string{"both", "new"}, dispatched)
+
+	newShards[1].dispatch(gateway.EventTypeMessageCreate, 2, gateway.EventMessageCreate{Message: discord.Message{Content: "after"}})
+	assert.Equal(t, []string{"both", "new", "after"}, dispatched)
+}
+
+func TestShardManager_ReshardGuilds(t *testing.T) {
+	var (
+		mu         sync.Mutex
+		dispatched []snowflake.ID
+		created    = make(chan *fakeGateway, 2)
+	)
+	eventHandlerFunc := func(_ gateway.EventType, _ int, _ int, event gateway.EventData) {
+		mu.Lock()
+		defer mu.Unlock()
+		if e, ok := event.(gateway.EventGuildCreate); ok {
+			dispatched = append(dispatched, e.ID)
+		}
+	}
+	createFunc := func(token string, eventHandlerFunc gateway.EventHandlerFunc, closeHandlerFunc gateway.CloseHandlerFunc, opts ...gateway.ConfigOpt) gateway.Gateway {
+		shard := newFakeGateway(token, eventHandlerFunc, closeHandlerFunc, opts...).(*fakeGateway)
+		created <- shard
+		return shard
+	}
+	guildCreate := func(guildID snowflake.ID) gateway.EventGuildCreate {
+		return gateway.EventGuildCreate{GatewayGuild: discord.GatewayGuild{RestGuild: discord.RestGuild{Guild: discord.Guild{ID: guildID}}}}
+	}
+
+	// guild 1 is cached, guild 2 is dispatched by the old shard & guild 3 is joined while resharding
+	m := New("", eventHandlerFunc, WithShardIDs(0), WithShardCount(1), WithGatewayCreateFunc(createFunc), WithGuildCachedFunc(func(guildID snowflake.ID) bool {
+		return guildID == 1
+	}))
+	assert.NoError(t, m.Open(context.Background()))
+	oldShard := <-created
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	done := make(chan error)
+	go func() {
+		done <- m.Reshard(ctx, 1)
+	}()
+	newShard := <-created
+
+	oldShard.dispatch(gateway.EventTypeGuildCreate, 10, guildCreate(2))
+	newShard.dispatch(gateway.EventTypeReady, 1, gateway.EventReady{Guilds: []discord.UnavailableGuild{{ID: 1}, {ID: 2}, {ID: 3}}})
+	newShard.dispatch(gateway.EventTypeGuildCreate, 2, guildCreate(1))
+	newShard.dispatch(gateway.EventTypeGuildCreate, 3, guildCreate(2))
+	newShard.dispatch(gateway.EventTypeGuildCreate, 4, guildCreate(3))
+
+	assert.NoError(t, <-done)
+	assert.True(t, oldShard.closed)
+	assert.Equal(t, []snowflake.ID{2, 3}, dispatched)
+}
+
+func TestShardManager_Cluster(t *testing.T) {
+	var forwarded []int
+	forwarder := ForwarderFunc(func(_ context.Context, clusterID int, shardID int, _ gateway.Opcode, _ gateway.MessageData) error {
+		forwarded = append(forwarded, clusterID, shardID)
+		return nil
+	})
+
+	m := New("", nil, WithShardCount(8), WithCluster(1, 3), WithGatewayCreateFunc(newFakeGateway), WithForwarder(forwarder))
+	assert.NoError(t, m.Open(context.Background()))
+	assert.Len(t, m.Shards(), 3)
+	for shardID := 3; shardID < 6; shardID++ {
+		assert.NotNil(t, m.Shard(shardID))
+	}
+
+	localGuildID := snowflake.ID(4 << 22)
+	remoteGuildID := snowflake.ID(7 << 22)
+	assert.True(t, m.IsLocalGuild(localGuildID))
+	assert.False(t, m.IsLocalGuild(remoteGuildID))
+	assert.Equal(t, 1, m.ClusterIDByGuildID(localGuildID))
+	assert.Equal(t, 2, m.ClusterIDByGuildID(remoteGuildID))
+	assert.Equal(t, m.Shard(4), m.ShardByGuildID(localGuildID))
+	assert.Nil(t, m.ShardByGuildID(remoteGuildID))
+
+	assert.NoError(t, m.SendToGuild(context.Background(), localGuildID, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{}))
+	assert.Equal(t, []gateway.Opcode{gateway.OpcodeVoiceStateUpdate}, m.Shard(4).(*fakeGateway).sent)
+
+	assert.NoError(t, m.SendToGuild(context.Background(), remoteGuildID, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{}))
+	assert.NoError(t, m.SendToShard(context.Background(), 0, gateway.OpcodePresenceUpdate, gateway.MessageDataPresenceUpdate{}))
+	assert.Equal(t, []int{2, 7, 0, 0}, forwarded)
+
+	assert.ErrorIs(t, m.SendToShard(context.Background(), 8, gateway.OpcodePresenceUpdate, gateway.MessageDataPresenceUpdate{}), discord.ErrShardNotFound)
+}
+
+func TestShardManager_ClusterUnknownShardCount(t *testing.T) {
+	// the shard count is only known after Open fetched the recommended one
+	m := New("", nil, WithCluster(1, 4))
+	assert.Equal(t, 1, m.ClusterIDByGuildID(123))
+	assert.False(t, m.IsLocalGuild(123))
+	assert.ErrorIs(t, m.SendToGuild(context.Background(), 123, gateway.OpcodeVoiceStateUpdate, gateway.MessageDataVoiceStateUpdate{}), discord.ErrShardNotFound)
+
+	assert.Equal(t, 0, ClusterIDByShardID(3, 0))
+}
@@ -3,10 +3,15 @@ package sharding
 import (
 	"context"
 
+	"github.com/disgoorg/disgo/gateway"
 	"github.com/disgoorg/log"
 )
 
+var _ gateway.IdentifyRateLimiter = (RateLimiter)(nil)
+
 // RateLimiter limits how many shards can log in to Discord at the same time.
+// It is used by the gateway.Gateway(s) of the ShardManager as gateway.IdentifyRateLimiter, so resuming shards do not wait for it.
+// Use NewHTTPRateLimiter if your shards are split across multiple processes.
 type RateLimiter interface {
 	// Logger returns the logger the RateLimiter uses
 	Logger() log.Logger
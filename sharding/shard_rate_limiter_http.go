@@ -0,0 +1,138 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultHTTPRateLimiterConfig returns a HTTPRateLimiterConfig with sensible defaults.
+func DefaultHTTPRateLimiterConfig() *HTTPRateLimiterConfig {
+	return &HTTPRateLimiterConfig{
+		Logger: log.Default(),
+		// no timeout, as waiting for a bucket can take a while
+		HTTPClient:    &http.Client{},
+		UnlockTimeout: 10 * time.Second,
+	}
+}
+
+// HTTPRateLimiterConfig lets you configure your HTTP RateLimiter instance.
+type HTTPRateLimiterConfig struct {
+	Logger     log.Logger
+	HTTPClient *http.Client
+
+	// Token is sent in the Authorization header and needs to match the one of the RateLimiterServer.
+	Token string
+
+	// UnlockTimeout is how long unlocking a bucket may take. The RateLimiterServer unlocks it after its lease if this fails.
+	UnlockTimeout time.Duration
+}
+
+// HTTPRateLimiterConfigOpt is a type alias for a function that takes a HTTPRateLimiterConfig and is used to configure your HTTP RateLimiter.
+type HTTPRateLimiterConfigOpt func(config *HTTPRateLimiterConfig)
+
+// Apply applies the given HTTPRateLimiterConfigOpt(s) to the HTTPRateLimiterConfig
+func (c *HTTPRateLimiterConfig) Apply(opts []HTTPRateLimiterConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithHTTPRateLimiterLogger sets the logger for the HTTP RateLimiter.
+func WithHTTPRateLimiterLogger(logger log.Logger) HTTPRateLimiterConfigOpt {
+	return func(config *HTTPRateLimiterConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithHTTPRateLimiterClient sets the http.Client used to talk to the RateLimiterServer.
+func WithHTTPRateLimiterClient(httpClient *http.Client) HTTPRateLimiterConfigOpt {
+	return func(config *HTTPRateLimiterConfig) {
+		config.HTTPClient = httpClient
+	}
+}
+
+// WithHTTPRateLimiterToken sets the token sent to the RateLimiterServer.
+func WithHTTPRateLimiterToken(token string) HTTPRateLimiterConfigOpt {
+	return func(config *HTTPRateLimiterConfig) {
+		config.Token = token
+	}
+}
+
+// WithHTTPRateLimiterUnlockTimeout sets how long unlocking a bucket may take.
+func WithHTTPRateLimiterUnlockTimeout(unlockTimeout time.Duration) HTTPRateLimiterConfigOpt {
+	return func(config *HTTPRateLimiterConfig) {
+		config.UnlockTimeout = unlockTimeout
+	}
+}
+
+var _ RateLimiter = (*httpRateLimiter)(nil)
+
+// NewHTTPRateLimiter returns a RateLimiter which locks the buckets on the RateLimiterServer at the given URL.
+// This lets shards which run in different processes share the max_concurrency of your bot.
+func NewHTTPRateLimiter(url string, opts ...HTTPRateLimiterConfigOpt) RateLimiter {
+	config := DefaultHTTPRateLimiterConfig()
+	config.Apply(opts)
+
+	return &httpRateLimiter{
+		url:    strings.TrimSuffix(url, "/"),
+		config: *config,
+	}
+}
+
+type httpRateLimiter struct {
+	url    string
+	config HTTPRateLimiterConfig
+}
+
+func (r *httpRateLimiter) Logger() log.Logger {
+	return r.config.Logger
+}
+
+func (r *httpRateLimiter) Close(_ context.Context) {
+	r.config.HTTPClient.CloseIdleConnections()
+}
+
+func (r *httpRateLimiter) WaitBucket(ctx context.Context, shardID int) error {
+	r.Logger().Debugf("locking shard bucket of shard %d on %s", shardID, r.url)
+	return r.do(ctx, "wait", shardID)
+}
+
+func (r *httpRateLimiter) UnlockBucket(shardID int) {
+	r.Logger().Debugf("unlocking shard bucket of shard %d on %s", shardID, r.url)
+	ctx, cancel := context.WithTimeout(context.Background(), r.config.UnlockTimeout)
+	defer cancel()
+	if err := r.do(ctx, "unlock", shardID); err != nil {
+		r.Logger().Errorf("failed to unlock shard bucket of shard %d: %s", shardID, err)
+	}
+}
+
+func (r *httpRateLimiter) do(ctx context.Context, action string, shardID int) error {
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, r.url+"/"+action+"?shard_id="+strconv.Itoa(shardID), nil)
+	if err != nil {
+		return err
+	}
+	if r.config.Token != "" {
+		rq.Header.Set("Authorization", r.config.Token)
+	}
+
+	rs, err := r.config.HTTPClient.Do(rq)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = rs.Body.Close()
+	}()
+
+	if rs.StatusCode != http.StatusNoContent {
+		body, _ := io.ReadAll(io.LimitReader(rs.Body, 512))
+		return fmt.Errorf("rate limiter server responded with %s: %s", rs.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -88,6 +88,7 @@ func (r *rateLimiterImpl) WaitBucket(ctx context.Context, shardID int) error {
 
 	if until.After(now) {
 		if deadline, ok := ctx.Deadline(); ok && until.After(deadline) {
+			b.mu.Unlock()
 			return context.DeadlineExceeded
 		}
 
@@ -0,0 +1,159 @@
+package sharding
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/log"
+)
+
+// DefaultRateLimiterServerConfig returns a RateLimiterServerConfig with sensible defaults.
+func DefaultRateLimiterServerConfig() *RateLimiterServerConfig {
+	return &RateLimiterServerConfig{
+		Logger: log.Default(),
+		Lease:  time.Minute,
+	}
+}
+
+// RateLimiterServerConfig lets you configure your RateLimiterServer instance.
+type RateLimiterServerConfig struct {
+	Logger log.Logger
+
+	// Lease is how long a bucket stays locked if it is not unlocked, e.g. because the process waiting for it crashed.
+	Lease time.Duration
+
+	// Token is required in the Authorization header of all requests if set.
+	Token string
+}
+
+// RateLimiterServerConfigOpt is a type alias for a function that takes a RateLimiterServerConfig and is used to configure your RateLimiterServer.
+type RateLimiterServerConfigOpt func(config *RateLimiterServerConfig)
+
+// Apply applies the given RateLimiterServerConfigOpt(s) to the RateLimiterServerConfig
+func (c *RateLimiterServerConfig) Apply(opts []RateLimiterServerConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithRateLimiterServerLogger sets the logger for the RateLimiterServer.
+func WithRateLimiterServerLogger(logger log.Logger) RateLimiterServerConfigOpt {
+	return func(config *RateLimiterServerConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithRateLimiterServerLease sets how long a bucket stays locked if it is not unlocked.
+func WithRateLimiterServerLease(lease time.Duration) RateLimiterServerConfigOpt {
+	return func(config *RateLimiterServerConfig) {
+		config.Lease = lease
+	}
+}
+
+// WithRateLimiterServerToken sets the token which is required in the Authorization header of all requests.
+func WithRateLimiterServerToken(token string) RateLimiterServerConfigOpt {
+	return func(config *RateLimiterServerConfig) {
+		config.Token = token
+	}
+}
+
+// NewRateLimiterServer returns a http.Handler which shares the buckets of the given RateLimiter with all RateLimiter(s) created by NewHTTPRateLimiter.
+// Run a single one for all processes of your bot, for example:
+//
+//	rateLimiter := sharding.NewRateLimiter(sharding.WithMaxConcurrency(16))
+//	http.ListenAndServe(":8080", sharding.NewRateLimiterServer(rateLimiter))
+//
+// It serves POST /wait?shard_id=<id> which responds once the bucket is locked and POST /unlock?shard_id=<id> and can be mounted under any prefix.
+func NewRateLimiterServer(rateLimiter RateLimiter, opts ...RateLimiterServerConfigOpt) http.Handler {
+	config := DefaultRateLimiterServerConfig()
+	config.Apply(opts)
+
+	return &rateLimiterServer{
+		rateLimiter: rateLimiter,
+		config:      *config,
+		leases:      map[int]*bucketLease{},
+	}
+}
+
+type rateLimiterServer struct {
+	rateLimiter RateLimiter
+	config      RateLimiterServerConfig
+
+	leases   map[int]*bucketLease
+	leasesMu sync.Mutex
+}
+
+type bucketLease struct {
+	timer *time.Timer
+}
+
+func (s *rateLimiterServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if s.config.Token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(s.config.Token)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	shardID, err := strconv.Atoi(r.URL.Query().Get("shard_id"))
+	if err != nil || shardID < 0 {
+		http.Error(w, "invalid shard_id", http.StatusBadRequest)
+		return
+	}
+
+	switch path.Base(r.URL.Path) {
+	case "wait":
+		if err = s.rateLimiter.WaitBucket(r.Context(), shardID); err != nil {
+			// the client stopped waiting
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		s.lease(shardID)
+
+	case "unlock":
+		if !s.unlock(shardID, nil) {
+			s.config.Logger.Debugf("shard bucket of shard %d was not locked", shardID)
+		}
+
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lease unlocks the bucket of the given shardID after the configured lease if it is not unlocked before.
+func (s *rateLimiterServer) lease(shardID int) {
+	s.leasesMu.Lock()
+	defer s.leasesMu.Unlock()
+
+	lease := &bucketLease{}
+	lease.timer = time.AfterFunc(s.config.Lease, func() {
+		if s.unlock(shardID, lease) {
+			s.config.Logger.Warnf("lease of shard bucket of shard %d expired", shardID)
+		}
+	})
+	s.leases[shardID] = lease
+}
+
+// unlock unlocks the bucket of the given shardID if it is locked and returns whether it was.
+// If expired is not nil, the bucket is only unlocked if it is still locked by that lease.
+func (s *rateLimiterServer) unlock(shardID int, expired *bucketLease) bool {
+	s.leasesMu.Lock()
+	lease, ok := s.leases[shardID]
+	if !ok || (expired != nil && lease != expired) {
+		s.leasesMu.Unlock()
+		return false
+	}
+	delete(s.leases, shardID)
+	s.leasesMu.Unlock()
+
+	lease.timer.Stop()
+	s.rateLimiter.UnlockBucket(shardID)
+	return true
+}
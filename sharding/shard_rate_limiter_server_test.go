@@ -0,0 +1,31 @@
+package sharding
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPRateLimiter(t *testing.T) {
+	server := httptest.NewServer(NewRateLimiterServer(NewRateLimiter(WithMaxConcurrency(2)), WithRateLimiterServerToken("token")))
+	defer server.Close()
+
+	rateLimiter := NewHTTPRateLimiter(server.URL, WithHTTPRateLimiterToken("token"))
+	defer rateLimiter.Close(context.Background())
+
+	assert.NoError(t, rateLimiter.WaitBucket(context.Background(), 0))
+
+	// shard 2 shares the bucket with shard 0
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	assert.Error(t, rateLimiter.WaitBucket(ctx, 2))
+
+	assert.NoError(t, rateLimiter.WaitBucket(context.Background(), 1))
+	rateLimiter.UnlockBucket(0)
+	rateLimiter.UnlockBucket(1)
+
+	assert.Error(t, NewHTTPRateLimiter(server.URL).WaitBucket(context.Background(), 0))
+}
@@ -0,0 +1,229 @@
+package sharding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"sync"
+
+	"github.com/disgoorg/disgo/gateway"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// reshard keeps track of a rolling reshard.
+// The events of the new shards are buffered until all of them are ready. Their READY & GUILD_CREATE events for guilds which are cached or were dispatched by the old shards are dropped.
+// The buffered events are dispatched after the old shards are closed, except the ones the old shards already dispatched.
+type reshard struct {
+	next        gateway.EventHandlerFunc
+	guildCached func(guildID snowflake.ID) bool
+
+	shards map[int]*reshardShard
+	ready  chan struct{}
+
+	seen   map[[sha256.Size]byte]int
+	guilds map[snowflake.ID]struct{}
+	seenMu sync.Mutex
+}
+
+type reshardShard struct {
+	mu       sync.Mutex
+	ready    bool
+	pending  map[snowflake.ID]struct{}
+	buffer   []bufferedEvent
+	flushed  bool
+	signaled bool
+}
+
+type bufferedEvent struct {
+	eventType      gateway.EventType
+	sequenceNumber int
+	event          gateway.EventData
+	fingerprint    [sha256.Size]byte
+	hasFingerprint bool
+
+	// guildID is set for the GUILD_CREATE events of guilds which were not known when they were buffered
+	guildID snowflake.ID
+}
+
+func newReshard(next gateway.EventHandlerFunc, guildCached func(guildID snowflake.ID) bool, shardIDs []int) *reshard {
+	shards := make(map[int]*reshardShard, len(shardIDs))
+	for _, shardID := range shardIDs {
+		shards[shardID] = &reshardShard{}
+	}
+	return &reshard{
+		next:        next,
+		guildCached: guildCached,
+		shards:      shards,
+		ready:       make(chan struct{}, len(shardIDs)),
+		seen:        map[[sha256.Size]byte]int{},
+		guilds:      map[snowflake.ID]struct{}{},
+	}
+}
+
+// dispatched remembers an event dispatched by an old shard, so it is not dispatched again by a new shard.
+// As the payload of a gateway.EventRaw can only be read once, the event to dispatch instead is returned.
+func (r *reshard) dispatched(eventType gateway.EventType, event gateway.EventData) gateway.EventData {
+	if isGatewayStatusEvent(eventType) {
+		return event
+	}
+	event, fingerprint, ok := eventFingerprint(eventType, event)
+	r.seenMu.Lock()
+	defer r.seenMu.Unlock()
+	if ok {
+		r.seen[fingerprint]++
+	}
+	if e, ok := event.(gateway.EventGuildCreate); ok {
+		r.guilds[e.ID] = struct{}{}
+	}
+	return event
+}
+
+// known returns whether the guild is cached or was dispatched by an old shard.
+func (r *reshard) known(guildID snowflake.ID) bool {
+	if r.guildCached == nil || r.guildCached(guildID) {
+		return true
+	}
+	r.seenMu.Lock()
+	defer r.seenMu.Unlock()
+	_, ok := r.guilds[guildID]
+	return ok
+}
+
+// eventHandlerFunc returns the gateway.EventHandlerFunc for the new shard with the given ID.
+func (r *reshard) eventHandlerFunc(shardID int) gateway.EventHandlerFunc {
+	shard := r.shards[shardID]
+	return func(eventType gateway.EventType, sequenceNumber int, shardID int, event gateway.EventData) {
+		if isGatewayStatusEvent(eventType) {
+			r.next(eventType, sequenceNumber, shardID, event)
+			return
+		}
+
+		// checked before locking the shard, as flush locks the shards while holding seenMu
+		var known bool
+		if e, ok := event.(gateway.EventGuildCreate); ok {
+			known = r.known(e.ID)
+		}
+
+		shard.mu.Lock()
+		defer shard.mu.Unlock()
+		if shard.flushed {
+			r.next(eventType, sequenceNumber, shardID, event)
+			return
+		}
+
+		switch e := event.(type) {
+		case gateway.EventReady:
+			shard.ready = true
+			shard.pending = make(map[snowflake.ID]struct{}, len(e.Guilds))
+			for _, guild := range e.Guilds {
+				shard.pending[guild.ID] = struct{}{}
+			}
+			shard.dropRaw(gateway.EventTypeReady)
+			r.checkReady(shard)
+			return
+
+		case gateway.EventGuildCreate:
+			if _, ok := shard.pending[e.ID]; ok {
+				delete(shard.pending, e.ID)
+				r.checkReady(shard)
+				if known {
+					shard.dropRaw(gateway.EventTypeGuildCreate)
+					return
+				}
+				// the guild was e.g. joined while resharding, so nobody dispatched it yet
+				shard.markRaw(gateway.EventTypeGuildCreate, e.ID)
+				shard.buffer = append(shard.buffer, bufferedEvent{
+					eventType:      eventType,
+					sequenceNumber: sequenceNumber,
+					event:          event,
+					guildID:        e.ID,
+				})
+				return
+			}
+		}
+
+		event, fingerprint, ok := eventFingerprint(eventType, event)
+		shard.buffer = append(shard.buffer, bufferedEvent{
+			eventType:      eventType,
+			sequenceNumber: sequenceNumber,
+			event:          event,
+			fingerprint:    fingerprint,
+			hasFingerprint: ok,
+		})
+	}
+}
+
+// checkReady signals once the shard received its READY and all guilds. It must be called while holding the mutex of the shard.
+func (r *reshard) checkReady(shard *reshardShard) {
+	if shard.signaled || !shard.ready || len(shard.pending) > 0 {
+		return
+	}
+	shard.signaled = true
+	r.ready <- struct{}{}
+}
+
+// dropRaw removes the gateway.EventRaw of the given type buffered right before its parsed event.
+func (s *reshardShard) dropRaw(eventType gateway.EventType) {
+	if len(s.buffer) == 0 {
+		return
+	}
+	if raw, ok := s.buffer[len(s.buffer)-1].event.(gateway.EventRaw); ok && raw.EventType == eventType {
+		s.buffer = s.buffer[:len(s.buffer)-1]
+	}
+}
+
+// markRaw sets the guild ID of the gateway.EventRaw of the given type buffered right before its parsed event.
+func (s *reshardShard) markRaw(eventType gateway.EventType, guildID snowflake.ID) {
+	if len(s.buffer) == 0 {
+		return
+	}
+	if raw, ok := s.buffer[len(s.buffer)-1].event.(gateway.EventRaw); ok && raw.EventType == eventType {
+		s.buffer[len(s.buffer)-1].guildID = guildID
+	}
+}
+
+// flush dispatches all buffered events which were not dispatched by the old shards and lets all further events pass.
+func (r *reshard) flush() {
+	r.seenMu.Lock()
+	defer r.seenMu.Unlock()
+	for shardID, shard := range r.shards {
+		shard.mu.Lock()
+		for _, e := range shard.buffer {
+			// an old shard dispatched the guild after it was buffered
+			if _, ok := r.guilds[e.guildID]; ok && e.guildID != 0 {
+				continue
+			}
+			if e.hasFingerprint && r.seen[e.fingerprint] > 0 {
+				r.seen[e.fingerprint]--
+				continue
+			}
+			r.next(e.eventType, e.sequenceNumber, shardID, e.event)
+		}
+		shard.buffer = nil
+		shard.flushed = true
+		shard.mu.Unlock()
+	}
+}
+
+// isGatewayStatusEvent returns whether the event is created by the gateway.Gateway itself and is never received by multiple shards.
+func isGatewayStatusEvent(eventType gateway.EventType) bool {
+	return eventType == gateway.EventTypeStatusChange || eventType == gateway.EventTypeZombied
+}
+
+// eventFingerprint returns a hash of the event which is equal for the same event received by different shards.
+func eventFingerprint(eventType gateway.EventType, event gateway.EventData) (gateway.EventData, [sha256.Size]byte, bool) {
+	if raw, ok := event.(gateway.EventRaw); ok {
+		data, err := io.ReadAll(raw.Payload)
+		if err != nil {
+			return event, [sha256.Size]byte{}, false
+		}
+		raw.Payload = bytes.NewReader(data)
+		return raw, sha256.Sum256(append([]byte(string(eventType)+":"+string(raw.EventType)+":"), data...)), true
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return event, [sha256.Size]byte{}, false
+	}
+	return event, sha256.Sum256(append([]byte(string(eventType)+":"), data...)), true
+}
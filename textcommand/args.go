@@ -0,0 +1,230 @@
+package textcommand
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// Split splits the given input into arguments like a shell does.
+// Arguments are separated by whitespace which can be kept by quoting them with " or '. A \ escapes the next character outside of ' quotes.
+func Split(input string) ([]string, error) {
+	var (
+		args    []string
+		current strings.Builder
+		inArg   bool
+		quote   rune
+		escaped bool
+	)
+	for _, r := range input {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, ErrUnclosedQuote
+	}
+	if escaped {
+		current.WriteRune('\\')
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// ParseSnowflake parses a snowflake.ID from a raw id or a mention of the given discord.MentionType.
+func ParseSnowflake(value string, mentionType discord.MentionType) (snowflake.ID, error) {
+	if matches := mentionType.FindStringSubmatch(value); matches != nil && matches[0] == value {
+		value = matches[1]
+	}
+	return snowflake.Parse(value)
+}
+
+func (e *Event) argumentError(index int, err error) error {
+	var value string
+	if index < len(e.Args) {
+		value = e.Args[index]
+	}
+	return &ArgumentError{Index: index, Value: value, Err: err}
+}
+
+// Arg returns the argument at the given index.
+func (e *Event) Arg(index int) (string, error) {
+	if index >= len(e.Args) {
+		return "", e.argumentError(index, ErrMissingArgument)
+	}
+	return e.Args[index], nil
+}
+
+// OptArg returns the argument at the given index or the fallback if it was not given.
+func (e *Event) OptArg(index int, fallback string) string {
+	if index >= len(e.Args) {
+		return fallback
+	}
+	return e.Args[index]
+}
+
+// RestArgs returns all arguments starting at the given index joined by a space.
+func (e *Event) RestArgs(index int) string {
+	if index >= len(e.Args) {
+		return ""
+	}
+	return strings.Join(e.Args[index:], " ")
+}
+
+// Int returns the argument at the given index as int.
+func (e *Event) Int(index int) (int, error) {
+	arg, err := e.Arg(index)
+	if err != nil {
+		return 0, err
+	}
+	i, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, e.argumentError(index, err)
+	}
+	return i, nil
+}
+
+// Duration returns the argument at the given index as time.Duration like "1h30m".
+func (e *Event) Duration(index int) (time.Duration, error) {
+	arg, err := e.Arg(index)
+	if err != nil {
+		return 0, err
+	}
+	duration, err := time.ParseDuration(arg)
+	if err != nil {
+		return 0, e.argumentError(index, err)
+	}
+	return duration, nil
+}
+
+func (e *Event) snowflake(index int, mentionType discord.MentionType) (snowflake.ID, error) {
+	arg, err := e.Arg(index)
+	if err != nil {
+		return 0, err
+	}
+	id, err := ParseSnowflake(arg, mentionType)
+	if err != nil {
+		return 0, e.argumentError(index, ErrInvalidID)
+	}
+	return id, nil
+}
+
+// Snowflake returns the argument at the given index as snowflake.ID. Mentions of users, roles & channels are accepted as well.
+func (e *Event) Snowflake(index int) (snowflake.ID, error) {
+	arg, err := e.Arg(index)
+	if err != nil {
+		return 0, err
+	}
+	for _, mentionType := range []discord.MentionType{discord.MentionTypeUser, discord.MentionTypeRole, discord.MentionTypeChannel} {
+		if id, err := ParseSnowflake(arg, mentionType); err == nil {
+			return id, nil
+		}
+	}
+	return 0, e.argumentError(index, ErrInvalidID)
+}
+
+// User returns the user mentioned or referenced by id at the given index.
+func (e *Event) User(index int) (*discord.User, error) {
+	userID, err := e.snowflake(index, discord.MentionTypeUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range e.Message.Mentions {
+		if user.ID == userID {
+			return &user, nil
+		}
+	}
+	user, err := e.Client().Rest().GetUser(userID)
+	if err != nil {
+		return nil, e.argumentError(index, err)
+	}
+	return user, nil
+}
+
+// Member returns the member mentioned or referenced by id at the given index.
+func (e *Event) Member(index int) (*discord.Member, error) {
+	if e.GuildID == nil {
+		return nil, e.argumentError(index, ErrNotInGuild)
+	}
+	userID, err := e.snowflake(index, discord.MentionTypeUser)
+	if err != nil {
+		return nil, err
+	}
+	if member, ok := e.Client().Caches().Members().Get(*e.GuildID, userID); ok {
+		return &member, nil
+	}
+	member, err := e.Client().Rest().GetMember(*e.GuildID, userID)
+	if err != nil {
+		return nil, e.argumentError(index, err)
+	}
+	return member, nil
+}
+
+// Channel returns the channel mentioned or referenced by id at the given index.
+func (e *Event) Channel(index int) (discord.Channel, error) {
+	channelID, err := e.snowflake(index, discord.MentionTypeChannel)
+	if err != nil {
+		return nil, err
+	}
+	if channel, ok := e.Client().Caches().Channels().Get(channelID); ok {
+		return channel, nil
+	}
+	channel, err := e.Client().Rest().GetChannel(channelID)
+	if err != nil {
+		return nil, e.argumentError(index, err)
+	}
+	return channel, nil
+}
+
+// Role returns the role mentioned or referenced by id at the given index.
+func (e *Event) Role(index int) (*discord.Role, error) {
+	if e.GuildID == nil {
+		return nil, e.argumentError(index, ErrNotInGuild)
+	}
+	roleID, err := e.snowflake(index, discord.MentionTypeRole)
+	if err != nil {
+		return nil, err
+	}
+	if role, ok := e.Client().Caches().Roles().Get(*e.GuildID, roleID); ok {
+		return &role, nil
+	}
+	roles, err := e.Client().Rest().GetRoles(*e.GuildID)
+	if err != nil {
+		return nil, e.argumentError(index, err)
+	}
+	for _, role := range roles {
+		if role.ID == roleID {
+			return &role, nil
+		}
+	}
+	return nil, e.argumentError(index, ErrNotFound)
+}
@@ -0,0 +1,58 @@
+package textcommand
+
+import (
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	data := []struct {
+		input string
+		args  []string
+		err   error
+	}{
+		{input: "", args: nil},
+		{input: "ban  @user   spam", args: []string{"ban", "@user", "spam"}},
+		{input: `say "hello world" 'it''s'`, args: []string{"say", "hello world", "its"}},
+		{input: `say \"quoted\" a\ b`, args: []string{"say", `"quoted"`, "a b"}},
+		{input: `say 'no \escape'`, args: []string{"say", `no \escape`}},
+		{input: `say ""`, args: []string{"say", ""}},
+		{input: `say "open`, err: ErrUnclosedQuote},
+	}
+
+	for _, d := range data {
+		args, err := Split(d.input)
+		assert.Equal(t, d.err, err, d.input)
+		assert.Equal(t, d.args, args, d.input)
+	}
+}
+
+func TestParseSnowflake(t *testing.T) {
+	data := []struct {
+		value       string
+		mentionType discord.MentionType
+		id          snowflake.ID
+		valid       bool
+	}{
+		{value: "170939974227591168", mentionType: discord.MentionTypeUser, id: 170939974227591168, valid: true},
+		{value: "<@170939974227591168>", mentionType: discord.MentionTypeUser, id: 170939974227591168, valid: true},
+		{value: "<@!170939974227591168>", mentionType: discord.MentionTypeUser, id: 170939974227591168, valid: true},
+		{value: "<@&170939974227591168>", mentionType: discord.MentionTypeRole, id: 170939974227591168, valid: true},
+		{value: "<#170939974227591168>", mentionType: discord.MentionTypeChannel, id: 170939974227591168, valid: true},
+		{value: "<#170939974227591168>", mentionType: discord.MentionTypeUser},
+		{value: "x<@170939974227591168>", mentionType: discord.MentionTypeUser},
+	}
+
+	for _, d := range data {
+		id, err := ParseSnowflake(d.value, d.mentionType)
+		if !d.valid {
+			assert.Error(t, err, d.value)
+			continue
+		}
+		assert.NoError(t, err, d.value)
+		assert.Equal(t, d.id, id, d.value)
+	}
+}
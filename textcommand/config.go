@@ -0,0 +1,128 @@
+package textcommand
+
+import (
+	"errors"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/log"
+)
+
+// DefaultConfig returns a Config with sensible defaults.
+func DefaultConfig() *Config {
+	return &Config{
+		Logger:          log.Default(),
+		Prefixes:        []string{"!"},
+		IgnoreBots:      true,
+		CaseInsensitive: true,
+		HelpCommand:     "help",
+		ErrorHandler:    DefaultErrorHandler,
+	}
+}
+
+// Config lets you configure your Router instance.
+type Config struct {
+	Logger log.Logger
+
+	// Prefixes are the prefixes commands can be called with.
+	Prefixes []string
+
+	// PrefixFunc returns the prefixes for the given message, e.g. per guild. If set, Prefixes are ignored.
+	PrefixFunc func(e *events.MessageCreate) []string
+
+	// MentionPrefix allows calling commands by mentioning the bot.
+	MentionPrefix bool
+
+	// IgnoreBots ignores messages from bots & webhooks.
+	IgnoreBots bool
+
+	// CaseInsensitive matches command names regardless of their case.
+	CaseInsensitive bool
+
+	// HelpCommand is the name of the generated help command. If empty, no help command is added.
+	HelpCommand string
+
+	// ErrorHandler is called with all errors returned by a Command or which happened while parsing it.
+	ErrorHandler func(e *Event, err error)
+}
+
+// ConfigOpt is a type alias for a function that takes a Config and is used to configure your Router.
+type ConfigOpt func(config *Config)
+
+// Apply applies the given ConfigOpt(s) to the Config
+func (c *Config) Apply(opts []ConfigOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithLogger sets the Logger of the Router.
+func WithLogger(logger log.Logger) ConfigOpt {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithPrefixes sets the prefixes commands can be called with.
+func WithPrefixes(prefixes ...string) ConfigOpt {
+	return func(config *Config) {
+		config.Prefixes = prefixes
+	}
+}
+
+// WithPrefixFunc sets the func which returns the prefixes for a message, e.g. per guild.
+func WithPrefixFunc(prefixFunc func(e *events.MessageCreate) []string) ConfigOpt {
+	return func(config *Config) {
+		config.PrefixFunc = prefixFunc
+	}
+}
+
+// WithMentionPrefix allows calling commands by mentioning the bot.
+func WithMentionPrefix(mentionPrefix bool) ConfigOpt {
+	return func(config *Config) {
+		config.MentionPrefix = mentionPrefix
+	}
+}
+
+// WithIgnoreBots sets whether messages from bots & webhooks are ignored.
+func WithIgnoreBots(ignoreBots bool) ConfigOpt {
+	return func(config *Config) {
+		config.IgnoreBots = ignoreBots
+	}
+}
+
+// WithCaseInsensitive sets whether command names are matched regardless of their case.
+func WithCaseInsensitive(caseInsensitive bool) ConfigOpt {
+	return func(config *Config) {
+		config.CaseInsensitive = caseInsensitive
+	}
+}
+
+// WithHelpCommand sets the name of the generated help command. An empty name disables it.
+func WithHelpCommand(helpCommand string) ConfigOpt {
+	return func(config *Config) {
+		config.HelpCommand = helpCommand
+	}
+}
+
+// WithErrorHandler sets the func which is called with all errors returned by a Command or which happened while parsing it.
+func WithErrorHandler(errorHandler func(e *Event, err error)) ConfigOpt {
+	return func(config *Config) {
+		config.ErrorHandler = errorHandler
+	}
+}
+
+// DefaultErrorHandler replies with argument & cooldown errors and logs all other errors.
+func DefaultErrorHandler(e *Event, err error) {
+	var (
+		argumentErr *ArgumentError
+		cooldownErr *CooldownError
+	)
+	if !errors.As(err, &argumentErr) && !errors.As(err, &cooldownErr) && !errors.Is(err, ErrUnclosedQuote) {
+		e.Client().Logger().Errorf("error while handling text command %s: %s", e.Command.Name, err)
+		return
+	}
+	if _, err = e.Reply(discord.MessageCreate{Content: err.Error()}); err != nil {
+		e.Client().Logger().Error("failed to reply with text command error: ", err)
+	}
+}
@@ -0,0 +1,252 @@
+package textcommand
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/cooldown"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/log"
+)
+
+var _ Router = (*routerImpl)(nil)
+
+// New creates a new Router with the given ConfigOpt(s).
+func New(opts ...ConfigOpt) Router {
+	config := DefaultConfig()
+	config.Apply(opts)
+
+	return &routerImpl{
+		config:    *config,
+		cooldowns: map[string]cooldown.Cooldown{},
+	}
+}
+
+type routerImpl struct {
+	config Config
+
+	commands    []Command
+	middlewares []Middleware
+	mu          sync.RWMutex
+
+	cooldowns   map[string]cooldown.Cooldown
+	cooldownsMu sync.Mutex
+}
+
+func (r *routerImpl) Logger() log.Logger {
+	return r.config.Logger
+}
+
+func (r *routerImpl) AddCommands(commands ...Command) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.commands = append(r.commands, commands...)
+}
+
+func (r *routerImpl) Commands() []Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]Command(nil), r.commands...)
+}
+
+func (r *routerImpl) Use(middlewares ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+func (r *routerImpl) OnEvent(event bot.Event) {
+	e, ok := event.(*events.MessageCreate)
+	if !ok {
+		return
+	}
+	if r.config.IgnoreBots && (e.Message.Author.Bot || e.Message.WebhookID != nil) {
+		return
+	}
+	prefix, content, ok := r.matchPrefix(e)
+	if !ok {
+		return
+	}
+
+	args, err := Split(content)
+	if err != nil {
+		r.config.ErrorHandler(&Event{MessageCreate: e, Prefix: prefix}, err)
+		return
+	}
+	if len(args) == 0 {
+		return
+	}
+
+	r.mu.RLock()
+	commands := r.commands
+	middlewares := append([]Middleware(nil), r.middlewares...)
+	r.mu.RUnlock()
+
+	command, ok := findCommand(commands, args[0], r.config.CaseInsensitive)
+	if !ok {
+		if r.config.HelpCommand != "" && equalName(r.config.HelpCommand, args[0], r.config.CaseInsensitive) {
+			r.handle(&Event{MessageCreate: e, Prefix: prefix, Path: []string{r.config.HelpCommand}, Args: args[1:]}, middlewares, r.handleHelp)
+		}
+		return
+	}
+	path := []string{command.Name}
+	middlewares = append(middlewares, command.Middlewares...)
+	args = args[1:]
+	for len(args) > 0 {
+		subcommand, ok := findCommand(command.Subcommands, args[0], r.config.CaseInsensitive)
+		if !ok {
+			break
+		}
+		command = subcommand
+		path = append(path, command.Name)
+		middlewares = append(middlewares, command.Middlewares...)
+		args = args[1:]
+	}
+
+	handler := command.Handler
+	if handler == nil {
+		handler = r.handleCommandHelp
+	} else if command.Cooldown > 0 {
+		handler = r.withCooldown(path, command.Cooldown, handler)
+	}
+	r.handle(&Event{MessageCreate: e, Prefix: prefix, Path: path, Command: command, Args: args}, middlewares, handler)
+}
+
+func (r *routerImpl) handle(e *Event, middlewares []Middleware, handler Handler) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	if err := handler(e); err != nil {
+		r.config.ErrorHandler(e, err)
+	}
+}
+
+func (r *routerImpl) matchPrefix(e *events.MessageCreate) (string, string, bool) {
+	prefixes := r.config.Prefixes
+	if r.config.PrefixFunc != nil {
+		prefixes = r.config.PrefixFunc(e)
+	}
+	if r.config.MentionPrefix {
+		botID := e.Client().ID().String()
+		prefixes = append([]string{"<@" + botID + ">", "<@!" + botID + ">"}, prefixes...)
+	}
+
+	content := e.Message.Content
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(content, prefix) {
+			return prefix, strings.TrimLeftFunc(content[len(prefix):], unicode.IsSpace), true
+		}
+	}
+	return "", "", false
+}
+
+// withCooldown wraps the Handler of the Command with the given path with a cooldown.Cooldown which allows one use per user and duration.
+func (r *routerImpl) withCooldown(path []string, duration time.Duration, handler Handler) Handler {
+	key := strings.Join(path, " ") + ":" + duration.String()
+
+	r.cooldownsMu.Lock()
+	c, ok := r.cooldowns[key]
+	if !ok {
+		c = cooldown.New(cooldown.WithScope(cooldown.ScopeUser), cooldown.WithRate(1, duration))
+		r.cooldowns[key] = c
+	}
+	r.cooldownsMu.Unlock()
+
+	return func(e *Event) error {
+		if remaining, ok := c.Take(cooldown.MessageSource(e.MessageCreate)); !ok {
+			return &CooldownError{Remaining: remaining}
+		}
+		return handler(e)
+	}
+}
+
+func (r *routerImpl) handleHelp(e *Event) error {
+	return r.replyHelp(e, e.Args...)
+}
+
+func (r *routerImpl) handleCommandHelp(e *Event) error {
+	return r.replyHelp(e, e.Path...)
+}
+
+func (r *routerImpl) replyHelp(e *Event, path ...string) error {
+	embed, ok := r.Help(e.Prefix, path...)
+	if !ok {
+		return &ArgumentError{Index: 0, Value: strings.Join(path, " "), Err: ErrNotFound}
+	}
+	_, err := e.Reply(discord.MessageCreate{Embeds: []discord.Embed{embed}})
+	return err
+}
+
+func (r *routerImpl) Help(prefix string, path ...string) (discord.Embed, bool) {
+	commands := r.Commands()
+	if len(path) == 0 {
+		embed := discord.NewEmbedBuilder().SetTitle("Commands")
+		embed.SetDescription(helpLines(prefix, commands))
+		if r.config.HelpCommand != "" {
+			embed.SetFooterText("Use " + prefix + r.config.HelpCommand + " <command> for more information about a command.")
+		}
+		return embed.Build(), true
+	}
+
+	var (
+		command Command
+		names   []string
+	)
+	for _, name := range path {
+		var ok bool
+		if command, ok = findCommand(commands, name, r.config.CaseInsensitive); !ok {
+			return discord.Embed{}, false
+		}
+		names = append(names, command.Name)
+		commands = command.Subcommands
+	}
+
+	embed := discord.NewEmbedBuilder().
+		SetTitle(strings.TrimSpace(prefix + strings.Join(names, " ") + " " + command.Usage)).
+		SetDescription(command.Description)
+	if len(command.Aliases) > 0 {
+		embed.AddField("Aliases", strings.Join(command.Aliases, ", "), false)
+	}
+	if lines := helpLines(prefix+strings.Join(names, " ")+" ", command.Subcommands); lines != "" {
+		embed.AddField("Subcommands", lines, false)
+	}
+	if command.Cooldown > 0 {
+		embed.AddField("Cooldown", command.Cooldown.String(), false)
+	}
+	return embed.Build(), true
+}
+
+func helpLines(prefix string, commands []Command) string {
+	var lines []string
+	for _, command := range commands {
+		if command.Hidden {
+			continue
+		}
+		line := "`" + prefix + command.Name + "`"
+		if command.Description != "" {
+			line += " - " + command.Description
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func findCommand(commands []Command, name string, caseInsensitive bool) (Command, bool) {
+	for _, command := range commands {
+		if command.matches(name, caseInsensitive) {
+			return command, true
+		}
+	}
+	return Command{}, false
+}
+
+func equalName(a string, b string, caseInsensitive bool) bool {
+	if caseInsensitive {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
@@ -0,0 +1,224 @@
+package textcommand
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/disgotest"
+	"github.com/disgoorg/disgo/events"
+)
+
+type testEnv struct {
+	client    bot.Client
+	server    disgotest.Server
+	channelID snowflake.ID
+}
+
+// newTestEnv returns a bot.Client talking to a disgotest.Server with one guild channel.
+func newTestEnv(t *testing.T) *testEnv {
+	server := disgotest.New()
+	t.Cleanup(server.Close)
+
+	client, err := disgo.New("MTIz.test.token", bot.WithRestClientConfigOpts(server.RestConfigOpts()...))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close(context.Background())
+	})
+	// usually set by the READY event
+	client.Caches().PutSelfUser(discord.OAuth2User{User: server.BotUser()})
+
+	guild := server.AddGuild(discord.Guild{Name: "Test Guild"})
+	channel := server.CreateGuildChannel(guild.ID, discord.GuildTextChannelCreate{Name: "general"})
+	return &testEnv{client: client, server: server, channelID: channel.ID()}
+}
+
+// message returns a MessageCreate of the given user sending the content to the channel of the testEnv.
+func (e *testEnv) message(userID snowflake.ID, content string) *events.MessageCreate {
+	message := e.server.AddMessage(discord.Message{
+		ChannelID: e.channelID,
+		Author:    discord.User{ID: userID, Username: "user"},
+		Content:   content,
+	})
+	return &events.MessageCreate{GenericMessage: &events.GenericMessage{
+		GenericEvent: events.NewGenericEvent(e.client, 0, 0),
+		MessageID:    message.ID,
+		Message:      message,
+		ChannelID:    e.channelID,
+	}}
+}
+
+// lastMessage returns the last message sent to the channel of the testEnv.
+func (e *testEnv) lastMessage(t *testing.T) discord.Message {
+	messages := e.server.Messages(e.channelID)
+	require.NotEmpty(t, messages)
+	return messages[len(messages)-1]
+}
+
+func TestRouter_OnEvent(t *testing.T) {
+	env := newTestEnv(t)
+
+	var (
+		handled *Event
+		calls   []string
+	)
+	handler := func(e *Event) error {
+		handled = e
+		return nil
+	}
+	middleware := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(e *Event) error {
+				calls = append(calls, name)
+				return next(e)
+			}
+		}
+	}
+
+	r := New(WithPrefixes("!", "?"), WithMentionPrefix(true))
+	r.Use(middleware("router"))
+	r.AddCommands(
+		Command{Name: "ping", Aliases: []string{"p"}, Handler: handler},
+		Command{
+			Name:        "config",
+			Middlewares: []Middleware{middleware("config")},
+			Subcommands: []Command{
+				{Name: "set", Middlewares: []Middleware{middleware("set")}, Handler: handler},
+			},
+		},
+	)
+
+	tests := []struct {
+		content string
+		prefix  string
+		path    []string
+		args    []string
+		calls   []string
+	}{
+		{content: "!ping", prefix: "!", path: []string{"ping"}, calls: []string{"router"}},
+		{content: "?P a b", prefix: "?", path: []string{"ping"}, args: []string{"a", "b"}, calls: []string{"router"}},
+		{content: "<@123> ping", prefix: "<@123>", path: []string{"ping"}, calls: []string{"router"}},
+		{content: `!config set prefix "$ "`, prefix: "!", path: []string{"config", "set"}, args: []string{"prefix", "$ "}, calls: []string{"router", "config", "set"}},
+		{content: "ping"},
+		{content: "!unknown"},
+		{content: "!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.content, func(t *testing.T) {
+			handled, calls = nil, nil
+			r.OnEvent(env.message(1000, tt.content))
+			if tt.path == nil {
+				assert.Nil(t, handled)
+				return
+			}
+			require.NotNil(t, handled)
+			assert.Equal(t, tt.prefix, handled.Prefix)
+			assert.Equal(t, tt.path, handled.Path)
+			if tt.args == nil {
+				assert.Empty(t, handled.Args)
+			} else {
+				assert.Equal(t, tt.args, handled.Args)
+			}
+			assert.Equal(t, tt.calls, calls)
+		})
+	}
+
+	t.Run("ignore bots", func(t *testing.T) {
+		handled = nil
+		e := env.message(1000, "!ping")
+		e.Message.Author.Bot = true
+		r.OnEvent(e)
+		assert.Nil(t, handled)
+	})
+}
+
+func TestRouter_Help(t *testing.T) {
+	env := newTestEnv(t)
+
+	r := New()
+	r.AddCommands(
+		Command{Name: "ping", Description: "Checks the latency", Handler: func(e *Event) error { return nil }},
+		Command{Name: "secret", Hidden: true, Handler: func(e *Event) error { return nil }},
+		Command{
+			Name:        "config",
+			Aliases:     []string{"cfg"},
+			Description: "Configures the bot",
+			Subcommands: []Command{
+				{Name: "set", Usage: "<key> <value>", Description: "Sets a value", Cooldown: time.Minute, Handler: func(e *Event) error { return nil }},
+			},
+		},
+	)
+
+	embed, ok := r.Help("!")
+	require.True(t, ok)
+	assert.Equal(t, "Commands", embed.Title)
+	assert.Equal(t, "`!ping` - Checks the latency\n`!config` - Configures the bot", embed.Description)
+	assert.Equal(t, "Use !help <command> for more information about a command.", embed.Footer.Text)
+
+	embed, ok = r.Help("!", "cfg", "set")
+	require.True(t, ok)
+	assert.Equal(t, "!config set <key> <value>", embed.Title)
+	assert.Equal(t, "Sets a value", embed.Description)
+	require.Len(t, embed.Fields, 1)
+	assert.Equal(t, "Cooldown", embed.Fields[0].Name)
+	assert.Equal(t, "1m0s", embed.Fields[0].Value)
+
+	_, ok = r.Help("!", "missing")
+	assert.False(t, ok)
+
+	// the help command and commands without a handler reply with the help
+	for content, title := range map[string]string{
+		"!help":        "Commands",
+		"!help config": "!config",
+		"!config":      "!config",
+	} {
+		r.OnEvent(env.message(1000, content))
+		message := env.lastMessage(t)
+		assert.Equal(t, env.server.BotUser().ID, message.Author.ID, content)
+		require.Len(t, message.Embeds, 1, content)
+		assert.Equal(t, title, message.Embeds[0].Title, content)
+	}
+
+	r.OnEvent(env.message(1000, "!help missing"))
+	assert.Contains(t, env.lastMessage(t).Content, ErrNotFound.Error())
+}
+
+func TestRouter_Cooldown(t *testing.T) {
+	env := newTestEnv(t)
+
+	var (
+		uses int
+		errs []error
+	)
+	r := New(WithErrorHandler(func(e *Event, err error) {
+		errs = append(errs, err)
+	}))
+	r.AddCommands(Command{
+		Name:     "daily",
+		Cooldown: time.Minute,
+		Handler: func(e *Event) error {
+			uses++
+			return nil
+		},
+	})
+
+	r.OnEvent(env.message(1000, "!daily"))
+	r.OnEvent(env.message(1000, "!daily"))
+	// the cooldown is per user
+	r.OnEvent(env.message(1001, "!daily"))
+
+	assert.Equal(t, 2, uses)
+	require.Len(t, errs, 1)
+	var cooldownErr *CooldownError
+	require.True(t, errors.As(errs[0], &cooldownErr))
+	assert.Greater(t, cooldownErr.Remaining, 59*time.Second)
+	assert.LessOrEqual(t, cooldownErr.Remaining, time.Minute)
+}
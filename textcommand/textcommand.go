@@ -0,0 +1,155 @@
+// Package textcommand provides a framework for message based commands like "!ban @user spamming".
+// Commands are matched by configurable prefixes, can have subcommands, cooldowns & middlewares and come with a generated help command.
+package textcommand
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/log"
+)
+
+var (
+	// ErrUnclosedQuote is returned when the arguments of a command contain a quote which is not closed.
+	ErrUnclosedQuote = errors.New("unclosed quote")
+
+	// ErrMissingArgument is returned when an argument is accessed which was not given.
+	ErrMissingArgument = errors.New("missing argument")
+
+	// ErrInvalidID is returned when an argument is neither an id nor a mention.
+	ErrInvalidID = errors.New("invalid id or mention")
+
+	// ErrNotFound is returned when the entity referenced by an argument does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrNotInGuild is returned when a guild only argument like a member or role is accessed outside a guild.
+	ErrNotInGuild = errors.New("command was not used in a guild")
+)
+
+// Handler handles a Command.
+type Handler func(e *Event) error
+
+// Middleware wraps a Handler to run code before and/or after it. It can stop the execution by not calling next.
+type Middleware func(next Handler) Handler
+
+// Command is a text command with optional subcommands.
+type Command struct {
+	// Name is the name the Command is called with.
+	Name string
+
+	// Aliases are alternative names the Command can be called with.
+	Aliases []string
+
+	// Description is shown in the help of the Command.
+	Description string
+
+	// Usage describes the arguments of the Command like "<user> [reason...]" and is shown in the help.
+	Usage string
+
+	// Hidden hides the Command from the help.
+	Hidden bool
+
+	// Cooldown is the time a user has to wait between two uses of the Command.
+	// For other scopes or limits use a cooldown.Cooldown in a Middleware instead.
+	Cooldown time.Duration
+
+	// Middlewares are run before the Handler. Middlewares of parent commands run first.
+	Middlewares []Middleware
+
+	// Subcommands are called with the name of the Command followed by their own name.
+	Subcommands []Command
+
+	// Handler handles the Command. If it is nil, the help of the Command is shown instead.
+	Handler Handler
+}
+
+func (c Command) matches(name string, caseInsensitive bool) bool {
+	if equalName(c.Name, name, caseInsensitive) {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if equalName(alias, name, caseInsensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is passed to the Handler of a Command.
+type Event struct {
+	*events.MessageCreate
+
+	// Prefix is the prefix the Command was called with.
+	Prefix string
+
+	// Path are the names of the Command and its parent commands.
+	Path []string
+
+	// Command is the called Command.
+	Command Command
+
+	// Args are the arguments after the name of the Command.
+	Args []string
+}
+
+// Reply sends the given discord.MessageCreate as reply to the message of the Event.
+func (e *Event) Reply(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
+	if messageCreate.MessageReference == nil {
+		messageCreate.MessageReference = &discord.MessageReference{MessageID: &e.MessageID}
+	}
+	return e.Client().Rest().CreateMessage(e.ChannelID, messageCreate, opts...)
+}
+
+// ArgumentError is returned when an argument could not be converted.
+type ArgumentError struct {
+	Index int
+	Value string
+	Err   error
+}
+
+func (e *ArgumentError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("argument %d: %s", e.Index+1, e.Err)
+	}
+	return fmt.Sprintf("argument %d (%q): %s", e.Index+1, e.Value, e.Err)
+}
+
+func (e *ArgumentError) Unwrap() error {
+	return e.Err
+}
+
+// CooldownError is returned when a user has to wait before using a Command again.
+type CooldownError struct {
+	Remaining time.Duration
+}
+
+func (e *CooldownError) Error() string {
+	return fmt.Sprintf("command is on cooldown, try again in %s", e.Remaining.Round(time.Second))
+}
+
+// Router handles *events.MessageCreate(s) and routes them to their Command.
+// It needs to be added as bot.EventListener to the bot.Client.
+// Commands are handled in the event listener, so long-running commands should either enable async events or start their own goroutine.
+type Router interface {
+	bot.EventListener
+
+	// Logger returns the logger used by the Router.
+	Logger() log.Logger
+
+	// AddCommands adds the given Command(s) to the Router.
+	AddCommands(commands ...Command)
+
+	// Commands returns all Command(s) of the Router.
+	Commands() []Command
+
+	// Use adds the given Middleware(s) which are run for all Command(s).
+	Use(middlewares ...Middleware)
+
+	// Help returns the help for the Command with the given path or a list of all Command(s) if the path is empty.
+	Help(prefix string, path ...string) (discord.Embed, bool)
+}
@@ -0,0 +1,454 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/log"
+)
+
+const (
+	// MaxContentLength is the maximum length of the content of a message.
+	MaxContentLength = 2000
+
+	// MaxEmbeds is the maximum number of embeds in a message.
+	MaxEmbeds = 10
+)
+
+// ErrBatcherClosed is returned when something is queued in a closed Batcher.
+var ErrBatcherClosed = errors.New("batcher is closed")
+
+// Batcher queues content & embeds and sends them with as few messages as possible through a Client.
+// This avoids hitting the rate limit of the webhook when sending many small messages like logs or alerts.
+type Batcher interface {
+	// Writer queues every written line as content.
+	io.Writer
+
+	// QueueContent queues the given content. Content longer than MaxContentLength is split into multiple messages.
+	QueueContent(content string) error
+
+	// QueueEmbeds queues the given discord.Embed(s).
+	QueueEmbeds(embeds ...discord.Embed) error
+
+	// Flush sends everything queued. If a message fails to be sent, it and all messages after it are queued again and the error is returned.
+	// Messages rejected by Discord as invalid are dropped instead, so they don't block the Batcher forever.
+	// Other client errors except rate limits, like a deleted webhook, drop all messages of the Flush, as they would fail the same way.
+	Flush(ctx context.Context) error
+
+	// Close stops the Batcher and flushes everything queued.
+	Close(ctx context.Context) error
+
+	// Logger returns a log.Logger which queues all logs of the given log.Level and above.
+	// Fatal & Panic logs are flushed before exiting or panicking.
+	Logger(level log.Level) log.Logger
+}
+
+// DefaultBatcherConfig returns a BatcherConfig with sensible defaults.
+func DefaultBatcherConfig() *BatcherConfig {
+	return &BatcherConfig{
+		Logger:          log.Default(),
+		FlushInterval:   2 * time.Second,
+		FlushSize:       50,
+		MaxQueueSize:    1000,
+		AllowedMentions: &discord.AllowedMentions{},
+	}
+}
+
+// BatcherConfig lets you configure your Batcher instance.
+type BatcherConfig struct {
+	Logger log.Logger
+
+	// FlushInterval is the interval in which everything queued is sent. If it is zero or negative, everything queued is only sent when the FlushSize is reached or Batcher.Flush is called.
+	FlushInterval time.Duration
+
+	// FlushSize is the number of queued items after which they are sent before the FlushInterval is over.
+	FlushSize int
+
+	// MaxQueueSize is the maximum number of queued items. If more are queued, the oldest ones are dropped. If it is zero or negative, the queue is unbounded.
+	MaxQueueSize int
+
+	Username        string
+	AvatarURL       string
+	AllowedMentions *discord.AllowedMentions
+}
+
+// BatcherOpt is a type alias for a function that takes a BatcherConfig and is used to configure your Batcher.
+type BatcherOpt func(config *BatcherConfig)
+
+// Apply applies the given BatcherOpt(s) to the BatcherConfig
+func (c *BatcherConfig) Apply(opts []BatcherOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithBatcherLogger sets the logger the Batcher reports failed flushes to. It must not be a Logger of the Batcher itself.
+func WithBatcherLogger(logger log.Logger) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithFlushInterval sets the interval in which everything queued is sent. A zero or negative interval disables flushing by time.
+func WithFlushInterval(flushInterval time.Duration) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.FlushInterval = flushInterval
+	}
+}
+
+// WithFlushSize sets the number of queued items after which they are sent before the flush interval is over.
+func WithFlushSize(flushSize int) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.FlushSize = flushSize
+	}
+}
+
+// WithMaxQueueSize sets the maximum number of queued items after which the oldest ones are dropped. A zero or negative size disables the limit.
+func WithMaxQueueSize(maxQueueSize int) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.MaxQueueSize = maxQueueSize
+	}
+}
+
+// WithBatcherUsername sets the username of all messages sent by the Batcher.
+func WithBatcherUsername(username string) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.Username = username
+	}
+}
+
+// WithBatcherAvatarURL sets the avatar url of all messages sent by the Batcher.
+func WithBatcherAvatarURL(avatarURL string) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.AvatarURL = avatarURL
+	}
+}
+
+// WithBatcherAllowedMentions sets the allowed mentions of all messages sent by the Batcher. By default, nobody is mentioned.
+func WithBatcherAllowedMentions(allowedMentions discord.AllowedMentions) BatcherOpt {
+	return func(config *BatcherConfig) {
+		config.AllowedMentions = &allowedMentions
+	}
+}
+
+var _ Batcher = (*batcherImpl)(nil)
+
+// NewBatcher creates a new Batcher which sends through the given Client.
+func NewBatcher(client Client, opts ...BatcherOpt) Batcher {
+	config := DefaultBatcherConfig()
+	config.Apply(opts)
+
+	b := &batcherImpl{
+		client:  client,
+		config:  *config,
+		flushCh: make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go b.loop()
+	return b
+}
+
+type batchItem struct {
+	content string
+	embed   *discord.Embed
+}
+
+type batcherImpl struct {
+	client Client
+	config BatcherConfig
+
+	queue  []batchItem
+	closed bool
+	mu     sync.Mutex
+
+	flushMu sync.Mutex
+	flushCh chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func (b *batcherImpl) loop() {
+	defer close(b.stopped)
+	// a nil channel blocks forever, so without an interval we only flush on demand
+	var tick <-chan time.Time
+	if b.config.FlushInterval > 0 {
+		ticker := time.NewTicker(b.config.FlushInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-tick:
+		case <-b.flushCh:
+		}
+		if err := b.Flush(context.Background()); err != nil {
+			b.config.Logger.Error("failed to flush webhook batcher: ", err)
+		}
+	}
+}
+
+func (b *batcherImpl) queueItems(items ...batchItem) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return ErrBatcherClosed
+	}
+	b.queue = append(b.queue, items...)
+	dropped := b.trimQueue()
+	if b.config.FlushSize > 0 && len(b.queue) >= b.config.FlushSize {
+		select {
+		case b.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	b.mu.Unlock()
+
+	b.logDropped(dropped)
+	return nil
+}
+
+// trimQueue drops the oldest items exceeding the MaxQueueSize and returns how many were dropped. It must be called while holding mu.
+func (b *batcherImpl) trimQueue() int {
+	dropped := len(b.queue) - b.config.MaxQueueSize
+	if b.config.MaxQueueSize <= 0 || dropped <= 0 {
+		return 0
+	}
+	b.queue = append([]batchItem(nil), b.queue[dropped:]...)
+	return dropped
+}
+
+func (b *batcherImpl) logDropped(dropped int) {
+	if dropped > 0 {
+		b.config.Logger.Warnf("dropped %d queued webhook batcher items as the max queue size of %d was reached", dropped, b.config.MaxQueueSize)
+	}
+}
+
+func (b *batcherImpl) Write(p []byte) (int, error) {
+	var items []batchItem
+	for _, line := range bytes.Split(bytes.TrimRight(p, "\r\n"), []byte("\n")) {
+		if line = bytes.TrimRight(line, "\r"); len(line) > 0 {
+			items = append(items, batchItem{content: string(line)})
+		}
+	}
+	if err := b.queueItems(items...); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (b *batcherImpl) QueueContent(content string) error {
+	return b.queueItems(batchItem{content: content})
+}
+
+func (b *batcherImpl) QueueEmbeds(embeds ...discord.Embed) error {
+	items := make([]batchItem, len(embeds))
+	for i := range embeds {
+		items[i] = batchItem{embed: &embeds[i]}
+	}
+	return b.queueItems(items...)
+}
+
+func (b *batcherImpl) Flush(ctx context.Context) error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	items := b.queue
+	b.queue = nil
+	b.mu.Unlock()
+
+	messages := batchMessages(items)
+	for i, messageCreate := range messages {
+		messageCreate.Username = b.config.Username
+		messageCreate.AvatarURL = b.config.AvatarURL
+		messageCreate.AllowedMentions = b.config.AllowedMentions
+		if _, err := b.client.CreateMessage(messageCreate, rest.WithCtx(ctx)); err != nil {
+			var restErr *rest.Error
+			if errors.As(err, &restErr) && restErr.Response != nil {
+				switch statusCode := restErr.Response.StatusCode; {
+				case statusCode == http.StatusBadRequest:
+					i++
+				case statusCode > http.StatusBadRequest && statusCode < http.StatusInternalServerError && statusCode != http.StatusTooManyRequests:
+					i = len(messages)
+				}
+			}
+			b.requeue(messages[i:])
+			return err
+		}
+	}
+	return nil
+}
+
+// requeue puts the items of the given unsent messages back in front of the queue, so they are sent with the next Flush.
+func (b *batcherImpl) requeue(messages []discord.WebhookMessageCreate) {
+	var items []batchItem
+	for _, message := range messages {
+		if message.Content != "" {
+			items = append(items, batchItem{content: message.Content})
+		}
+		for i := range message.Embeds {
+			items = append(items, batchItem{embed: &message.Embeds[i]})
+		}
+	}
+
+	b.mu.Lock()
+	b.queue = append(items, b.queue...)
+	dropped := b.trimQueue()
+	b.mu.Unlock()
+
+	b.logDropped(dropped)
+}
+
+func (b *batcherImpl) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.done)
+	<-b.stopped
+	return b.Flush(ctx)
+}
+
+func (b *batcherImpl) Logger(level log.Level) log.Logger {
+	return &batcherLogger{batcher: b, level: level}
+}
+
+// batchMessages coalesces the given items into as few messages as possible while keeping their order.
+func batchMessages(items []batchItem) []discord.WebhookMessageCreate {
+	var (
+		messages      []discord.WebhookMessageCreate
+		current       discord.WebhookMessageCreate
+		contentLength int
+	)
+	finish := func() {
+		if current.Content != "" || len(current.Embeds) > 0 {
+			messages = append(messages, current)
+		}
+		current = discord.WebhookMessageCreate{}
+		contentLength = 0
+	}
+
+	for _, item := range items {
+		// embeds are shown below the content, so content after embeds needs a new message to keep the order
+		if item.embed != nil {
+			if len(current.Embeds) == MaxEmbeds {
+				finish()
+			}
+			current.Embeds = append(current.Embeds, *item.embed)
+			continue
+		}
+		if len(current.Embeds) > 0 {
+			finish()
+		}
+		for _, chunk := range splitContent(item.content) {
+			chunkLength := utf8.RuneCountInString(chunk)
+			if current.Content != "" && contentLength+1+chunkLength > MaxContentLength {
+				finish()
+			}
+			if current.Content != "" {
+				current.Content += "\n"
+				contentLength++
+			}
+			current.Content += chunk
+			contentLength += chunkLength
+		}
+	}
+	finish()
+	return messages
+}
+
+// splitContent splits the content into chunks of at most MaxContentLength characters preferring line breaks.
+func splitContent(content string) []string {
+	var chunks []string
+	for utf8.RuneCountInString(content) > MaxContentLength {
+		// end is the byte offset after the last rune which still fits
+		var end, runes int
+		for end = range content {
+			if runes == MaxContentLength {
+				break
+			}
+			runes++
+		}
+		if i := strings.LastIndexByte(content[:end], '\n'); i > 0 {
+			chunks = append(chunks, content[:i])
+			content = content[i+1:]
+			continue
+		}
+		chunks = append(chunks, content[:end])
+		content = content[end:]
+	}
+	if content != "" {
+		chunks = append(chunks, content)
+	}
+	return chunks
+}
+
+var _ log.Logger = (*batcherLogger)(nil)
+
+type batcherLogger struct {
+	batcher *batcherImpl
+	level   log.Level
+}
+
+func (l *batcherLogger) output(level log.Level, s string) {
+	if level < l.level {
+		return
+	}
+	_ = l.batcher.QueueContent("`" + strings.TrimSpace(level.String()) + "` " + s)
+
+	switch level {
+	case log.LevelFatal:
+		_ = l.batcher.Flush(context.Background())
+		os.Exit(1)
+	case log.LevelPanic:
+		_ = l.batcher.Flush(context.Background())
+		panic(s)
+	}
+}
+
+func (l *batcherLogger) Trace(args ...any) { l.output(log.LevelTrace, fmt.Sprint(args...)) }
+func (l *batcherLogger) Debug(args ...any) { l.output(log.LevelDebug, fmt.Sprint(args...)) }
+func (l *batcherLogger) Info(args ...any)  { l.output(log.LevelInfo, fmt.Sprint(args...)) }
+func (l *batcherLogger) Warn(args ...any)  { l.output(log.LevelWarn, fmt.Sprint(args...)) }
+func (l *batcherLogger) Error(args ...any) { l.output(log.LevelError, fmt.Sprint(args...)) }
+func (l *batcherLogger) Fatal(args ...any) { l.output(log.LevelFatal, fmt.Sprint(args...)) }
+func (l *batcherLogger) Panic(args ...any) { l.output(log.LevelPanic, fmt.Sprint(args...)) }
+func (l *batcherLogger) Tracef(format string, args ...any) {
+	l.output(log.LevelTrace, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Debugf(format string, args ...any) {
+	l.output(log.LevelDebug, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Infof(format string, args ...any) {
+	l.output(log.LevelInfo, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Warnf(format string, args ...any) {
+	l.output(log.LevelWarn, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Errorf(format string, args ...any) {
+	l.output(log.LevelError, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Fatalf(format string, args ...any) {
+	l.output(log.LevelFatal, fmt.Sprintf(format, args...))
+}
+func (l *batcherLogger) Panicf(format string, args ...any) {
+	l.output(log.LevelPanic, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchMessages(t *testing.T) {
+	embed := discord.Embed{Description: "embed"}
+	items := []batchItem{
+		{content: "a"},
+		{content: "b"},
+		{embed: &embed},
+		{content: "c"},
+		{content: strings.Repeat("x", 1999)},
+	}
+	for i := 0; i < 11; i++ {
+		items = append(items, batchItem{embed: &embed})
+	}
+
+	messages := batchMessages(items)
+
+	assert.Len(t, messages, 4)
+	assert.Equal(t, "a\nb", messages[0].Content)
+	assert.Len(t, messages[0].Embeds, 1)
+	assert.Equal(t, "c", messages[1].Content)
+	assert.Equal(t, strings.Repeat("x", 1999), messages[2].Content)
+	assert.Len(t, messages[2].Embeds, 10)
+	assert.Len(t, messages[3].Embeds, 1)
+}
+
+func TestSplitContent(t *testing.T) {
+	chunks := splitContent(strings.Repeat("ä", 2500))
+	assert.Len(t, chunks, 2)
+	assert.Equal(t, 2000, len([]rune(chunks[0])))
+	assert.Equal(t, 500, len([]rune(chunks[1])))
+
+	chunks = splitContent(strings.Repeat("a", 1500) + "\n" + strings.Repeat("b", 1000))
+	assert.Equal(t, []string{strings.Repeat("a", 1500), strings.Repeat("b", 1000)}, chunks)
+}
+
+func TestNewWithURL(t *testing.T) {
+	client, err := NewWithURL("https://discord.com/api/webhooks/170939974227591168/token?thread_id=170939974227591169")
+	assert.NoError(t, err)
+	assert.Equal(t, "170939974227591168", client.ID().String())
+	assert.Equal(t, "token", client.Token())
+	assert.Equal(t, "170939974227591169", client.ThreadID().String())
+	assert.True(t, strings.HasSuffix(client.URL(), "/webhooks/170939974227591168/token?thread_id=170939974227591169"))
+
+	_, err = NewWithURL("https://discord.com/api/channels/170939974227591168")
+	assert.ErrorIs(t, err, ErrInvalidWebhookURL)
+}
+
+// testWebhookServer answers execute webhook requests with the given status codes in order and 200 afterwards.
+type testWebhookServer struct {
+	statuses []int
+	contents []string
+	mu       sync.Mutex
+}
+
+func (s *testWebhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var messageCreate discord.WebhookMessageCreate
+	_ = json.Unmarshal(body, &messageCreate)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	if status == http.StatusOK {
+		s.contents = append(s.contents, messageCreate.Content)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`{"id": "1", "channel_id": "2"}`))
+}
+
+func TestBatcher_Flush(t *testing.T) {
+	handler := &testWebhookServer{statuses: []int{http.StatusInternalServerError, http.StatusBadRequest}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := New(1, "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+	defer client.Close(context.Background())
+	// no interval, so only Flush sends
+	batcher := NewBatcher(client, WithFlushInterval(0), WithFlushSize(0))
+
+	require.NoError(t, batcher.QueueContent("a"))
+	require.NoError(t, batcher.QueueEmbeds(discord.Embed{Description: "embed"}))
+	require.NoError(t, batcher.QueueContent("b"))
+
+	// the failed messages are queued again in front of new ones
+	assert.Error(t, batcher.Flush(context.Background()))
+	require.NoError(t, batcher.QueueContent("c"))
+
+	// the invalid message is dropped
+	assert.Error(t, batcher.Flush(context.Background()))
+	assert.NoError(t, batcher.Flush(context.Background()))
+	assert.NoError(t, batcher.Close(context.Background()))
+
+	assert.Equal(t, []string{"b\nc"}, handler.contents)
+}
+
+func TestBatcher_FlushWebhookNotFound(t *testing.T) {
+	handler := &testWebhookServer{statuses: []int{http.StatusNotFound}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := New(1, "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+	defer client.Close(context.Background())
+	batcher := NewBatcher(client, WithFlushInterval(0), WithFlushSize(0))
+
+	require.NoError(t, batcher.QueueContent("a"))
+	require.NoError(t, batcher.QueueContent(strings.Repeat("x", 1999)))
+
+	// the messages would fail the same way again, so all of them are dropped instead of queued again
+	assert.Error(t, batcher.Flush(context.Background()))
+	assert.NoError(t, batcher.Flush(context.Background()))
+	assert.Empty(t, handler.contents)
+
+	require.NoError(t, batcher.QueueContent("b"))
+	assert.NoError(t, batcher.Close(context.Background()))
+	assert.Equal(t, []string{"b"}, handler.contents)
+}
+
+func TestBatcher_MaxQueueSize(t *testing.T) {
+	handler := &testWebhookServer{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	client := New(1, "token", WithRestClientConfigOpts(rest.WithURL(server.URL)))
+	defer client.Close(context.Background())
+	batcher := NewBatcher(client, WithFlushInterval(0), WithFlushSize(0), WithMaxQueueSize(2))
+
+	require.NoError(t, batcher.QueueContent("a"))
+	require.NoError(t, batcher.QueueContent("b"))
+	require.NoError(t, batcher.QueueContent("c"))
+
+	// the oldest items are dropped to stay within the limit, including the failed message queued again
+	assert.Error(t, batcher.Flush(context.Background()))
+	require.NoError(t, batcher.QueueContent("d"))
+	require.NoError(t, batcher.QueueContent("e"))
+	assert.NoError(t, batcher.Close(context.Background()))
+
+	assert.Equal(t, []string{"d\ne"}, handler.contents)
+}
@@ -0,0 +1,344 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+var (
+	// ErrPoolEmpty is returned when a Pool has no webhooks left to send with.
+	ErrPoolEmpty = errors.New("webhook pool has no webhooks")
+
+	// ErrNoPoolChannel is returned when a Pool should create webhooks but no channel is configured.
+	ErrNoPoolChannel = errors.New("webhook pool has no channel to create webhooks in")
+)
+
+// Pool balances messages between multiple webhooks of the same channel, so they are not limited by the rate limit of a single webhook.
+// All webhooks share one rest.Client, so the rate limit state of each webhook is known when choosing which one to send with.
+type Pool interface {
+	// Clients returns the Client(s) of all webhooks in the Pool.
+	Clients() []Client
+
+	// CreateMessage creates a new Message with the webhook whose rate limit resets first.
+	CreateMessage(messageCreate discord.WebhookMessageCreate, opts ...rest.RequestOpt) (*discord.Message, error)
+
+	// CreateMessageInStream creates a new Message like CreateMessage but waits for all previous messages of the same stream to be sent first.
+	// Messages of one stream are therefore never reordered, while different streams are sent in parallel.
+	CreateMessageInStream(stream string, messageCreate discord.WebhookMessageCreate, opts ...rest.RequestOpt) (*discord.Message, error)
+
+	// Close closes the shared rest.Client of the Pool.
+	Close(ctx context.Context)
+}
+
+// DefaultPoolConfig returns a PoolConfig with sensible defaults.
+func DefaultPoolConfig() *PoolConfig {
+	return &PoolConfig{
+		Logger:      log.Default(),
+		WebhookName: "disgo pool",
+	}
+}
+
+// PoolConfig lets you configure your Pool instance.
+type PoolConfig struct {
+	Logger               log.Logger
+	RestClient           rest.Client
+	RestClientConfigOpts []rest.ConfigOpt
+
+	// Webhooks are the id & token of existing webhooks to use.
+	Webhooks map[snowflake.ID]string
+
+	// Channels is used to create webhooks in the ChannelID. It needs a rest.Client with a bot token.
+	Channels  rest.Channels
+	ChannelID snowflake.ID
+
+	// Size is the number of webhooks the Pool creates in the ChannelID if it has fewer. Removed webhooks are replaced as well.
+	Size int
+
+	// WebhookName is the name of webhooks created by the Pool. Existing webhooks with this name in the channel are reused.
+	WebhookName string
+
+	// OnFailure is called when sending a message with a webhook failed.
+	OnFailure func(client Client, err error)
+}
+
+// PoolOpt is a type alias for a function that takes a PoolConfig and is used to configure your Pool.
+type PoolOpt func(config *PoolConfig)
+
+// Apply applies the given PoolOpt(s) to the PoolConfig
+func (c *PoolConfig) Apply(opts []PoolOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.RestClient == nil {
+		c.RestClient = rest.NewClient("", c.RestClientConfigOpts...)
+	}
+}
+
+// WithPoolLogger sets the logger of the Pool.
+func WithPoolLogger(logger log.Logger) PoolOpt {
+	return func(config *PoolConfig) {
+		config.Logger = logger
+	}
+}
+
+// WithPoolRestClient sets the rest.Client shared by all webhooks of the Pool.
+func WithPoolRestClient(restClient rest.Client) PoolOpt {
+	return func(config *PoolConfig) {
+		config.RestClient = restClient
+	}
+}
+
+// WithPoolRestClientConfigOpts sets the rest.ConfigOpt(s) of the rest.Client shared by all webhooks of the Pool.
+func WithPoolRestClientConfigOpts(opts ...rest.ConfigOpt) PoolOpt {
+	return func(config *PoolConfig) {
+		config.RestClientConfigOpts = append(config.RestClientConfigOpts, opts...)
+	}
+}
+
+// WithPoolWebhook adds an existing webhook by id & token to the Pool.
+func WithPoolWebhook(id snowflake.ID, token string) PoolOpt {
+	return func(config *PoolConfig) {
+		if config.Webhooks == nil {
+			config.Webhooks = map[snowflake.ID]string{}
+		}
+		config.Webhooks[id] = token
+	}
+}
+
+// WithPoolChannel lets the Pool create up to size webhooks in the given channel and replace removed ones.
+func WithPoolChannel(channels rest.Channels, channelID snowflake.ID, size int) PoolOpt {
+	return func(config *PoolConfig) {
+		config.Channels = channels
+		config.ChannelID = channelID
+		config.Size = size
+	}
+}
+
+// WithPoolWebhookName sets the name of webhooks created by the Pool.
+func WithPoolWebhookName(webhookName string) PoolOpt {
+	return func(config *PoolConfig) {
+		config.WebhookName = webhookName
+	}
+}
+
+// WithPoolOnFailure sets the func which is called when sending a message with a webhook failed.
+func WithPoolOnFailure(onFailure func(client Client, err error)) PoolOpt {
+	return func(config *PoolConfig) {
+		config.OnFailure = onFailure
+	}
+}
+
+var _ Pool = (*poolImpl)(nil)
+
+// NewPool creates a new Pool with the given PoolOpt(s).
+// If a channel is configured, existing webhooks of the Pool in it are reused and missing ones are created.
+func NewPool(ctx context.Context, opts ...PoolOpt) (Pool, error) {
+	config := DefaultPoolConfig()
+	config.Apply(opts)
+
+	p := &poolImpl{
+		config:  *config,
+		streams: map[string]*poolStream{},
+	}
+	for id, token := range config.Webhooks {
+		p.clients = append(p.clients, p.newClient(id, token))
+	}
+
+	if config.Channels != nil {
+		webhooks, err := config.Channels.GetWebhooks(config.ChannelID, rest.WithCtx(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, webhook := range webhooks {
+			incomingWebhook, ok := webhook.(discord.IncomingWebhook)
+			if !ok || incomingWebhook.Name() != config.WebhookName || incomingWebhook.Token == "" || len(p.clients) >= config.Size {
+				continue
+			}
+			p.clients = append(p.clients, p.newClient(incomingWebhook.ID(), incomingWebhook.Token))
+		}
+		for len(p.clients) < config.Size {
+			client, err := p.createClient(ctx)
+			if err != nil {
+				return nil, err
+			}
+			p.clients = append(p.clients, client)
+		}
+	}
+
+	if len(p.clients) == 0 {
+		return nil, ErrPoolEmpty
+	}
+	return p, nil
+}
+
+type poolStream struct {
+	mu   sync.Mutex
+	refs int
+}
+
+type poolImpl struct {
+	config PoolConfig
+
+	clients   []Client
+	next      int
+	clientsMu sync.Mutex
+
+	streams   map[string]*poolStream
+	streamsMu sync.Mutex
+}
+
+func (p *poolImpl) newClient(id snowflake.ID, token string) Client {
+	return New(id, token, WithLogger(p.config.Logger), WithRestClient(p.config.RestClient))
+}
+
+func (p *poolImpl) createClient(ctx context.Context) (Client, error) {
+	if p.config.Channels == nil {
+		return nil, ErrNoPoolChannel
+	}
+	webhook, err := p.config.Channels.CreateWebhook(p.config.ChannelID, discord.WebhookCreate{Name: p.config.WebhookName}, rest.WithCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return p.newClient(webhook.ID(), webhook.Token), nil
+}
+
+func (p *poolImpl) Clients() []Client {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	return append([]Client(nil), p.clients...)
+}
+
+// pick returns the Client whose bucket is available first. Clients are tried round-robin if the rest.RateLimiter can't be inspected.
+func (p *poolImpl) pick() (Client, error) {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	if len(p.clients) == 0 {
+		return nil, ErrPoolEmpty
+	}
+
+	inspector, ok := p.config.RestClient.RateLimiter().(rest.BucketInspector)
+	if !ok {
+		client := p.clients[p.next%len(p.clients)]
+		p.next++
+		return client, nil
+	}
+
+	var (
+		best          int
+		bestAvailable time.Time
+		now           = time.Now()
+	)
+	for i := range p.clients {
+		index := (p.next + i) % len(p.clients)
+		client := p.clients[index]
+		compiledRoute, err := route.CreateWebhookMessage.Compile(nil, client.ID(), client.Token())
+		if err != nil {
+			continue
+		}
+		availableAt, inUse := inspector.BucketAvailableAt(compiledRoute)
+		if availableAt.Before(now) {
+			availableAt = now
+		}
+		if inUse {
+			// we don't know how long the request takes, so prefer any idle webhook
+			availableAt = availableAt.Add(time.Second)
+		}
+		if i == 0 || availableAt.Before(bestAvailable) {
+			best = index
+			bestAvailable = availableAt
+		}
+	}
+	p.next = best + 1
+	return p.clients[best], nil
+}
+
+func (p *poolImpl) remove(client Client) bool {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+	for i, c := range p.clients {
+		if c == client {
+			p.clients = append(p.clients[:i], p.clients[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (p *poolImpl) CreateMessage(messageCreate discord.WebhookMessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
+	for {
+		client, err := p.pick()
+		if err != nil {
+			return nil, err
+		}
+		message, err := client.CreateMessage(messageCreate, opts...)
+		if err == nil {
+			return message, nil
+		}
+		if p.config.OnFailure != nil {
+			p.config.OnFailure(client, err)
+		}
+		if !isWebhookGone(err) {
+			return nil, err
+		}
+
+		// the webhook got deleted, so we replace it and try again with another one
+		if !p.remove(client) {
+			continue
+		}
+		p.config.Logger.Warnf("webhook %s of pool got removed", client.ID())
+		if p.config.Channels != nil {
+			replacement, err := p.createClient(context.Background())
+			if err != nil {
+				p.config.Logger.Error("failed to replace removed webhook of pool: ", err)
+				continue
+			}
+			p.clientsMu.Lock()
+			p.clients = append(p.clients, replacement)
+			p.clientsMu.Unlock()
+		}
+	}
+}
+
+func (p *poolImpl) CreateMessageInStream(stream string, messageCreate discord.WebhookMessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
+	p.streamsMu.Lock()
+	s, ok := p.streams[stream]
+	if !ok {
+		s = &poolStream{}
+		p.streams[stream] = s
+	}
+	s.refs++
+	p.streamsMu.Unlock()
+
+	s.mu.Lock()
+	defer func() {
+		s.mu.Unlock()
+		p.streamsMu.Lock()
+		if s.refs--; s.refs == 0 {
+			delete(p.streams, stream)
+		}
+		p.streamsMu.Unlock()
+	}()
+	return p.CreateMessage(messageCreate, opts...)
+}
+
+func (p *poolImpl) Close(ctx context.Context) {
+	p.config.RestClient.Close(ctx)
+}
+
+// isWebhookGone returns whether the error means the webhook does not exist anymore or its token got reset.
+func isWebhookGone(err error) bool {
+	var restErr *rest.Error
+	if !errors.As(err, &restErr) || restErr.Response == nil {
+		return false
+	}
+	return restErr.Response.StatusCode == http.StatusNotFound || restErr.Response.StatusCode == http.StatusUnauthorized
+}
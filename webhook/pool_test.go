@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/disgo/rest/route"
+	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRateLimiter struct {
+	availableAt map[string]time.Time
+}
+
+func (l *testRateLimiter) Logger() log.Logger                                        { return log.Default() }
+func (l *testRateLimiter) MaxRetries() int                                           { return 0 }
+func (l *testRateLimiter) Close(context.Context)                                     {}
+func (l *testRateLimiter) Reset()                                                    {}
+func (l *testRateLimiter) WaitBucket(context.Context, *route.CompiledAPIRoute) error { return nil }
+func (l *testRateLimiter) UnlockBucket(*route.CompiledAPIRoute, *http.Response) error {
+	return nil
+}
+
+func (l *testRateLimiter) BucketAvailableAt(route *route.CompiledAPIRoute) (time.Time, bool) {
+	return l.availableAt[route.MajorParams()], false
+}
+
+func TestPool_Pick(t *testing.T) {
+	rateLimiter := &testRateLimiter{availableAt: map[string]time.Time{}}
+	p := &poolImpl{config: PoolConfig{
+		Logger:     log.Default(),
+		RestClient: rest.NewClient("", rest.WithRateLimiter(rateLimiter)),
+	}}
+	for i := 1; i <= 3; i++ {
+		client := p.newClient(snowflake.ID(i), "token")
+		p.clients = append(p.clients, client)
+		compiledRoute, _ := route.CreateWebhookMessage.Compile(nil, client.ID(), client.Token())
+		rateLimiter.availableAt[compiledRoute.MajorParams()] = time.Now().Add(time.Duration(i) * time.Minute)
+	}
+
+	client, err := p.pick()
+	assert.NoError(t, err)
+	assert.Equal(t, snowflake.ID(1), client.ID())
+
+	compiledRoute, _ := route.CreateWebhookMessage.Compile(nil, snowflake.ID(3), "token")
+	rateLimiter.availableAt[compiledRoute.MajorParams()] = time.Time{}
+
+	client, err = p.pick()
+	assert.NoError(t, err)
+	assert.Equal(t, snowflake.ID(3), client.ID())
+}
@@ -14,6 +14,8 @@ type Client interface {
 	ID() snowflake.ID
 	// Token returns the configured Webhook token
 	Token() string
+	// ThreadID returns the configured thread id messages are sent to by default
+	ThreadID() snowflake.ID
 	// URL returns the full Webhook URL
 	URL() string
 	// Close closes all connections the Webhook Client has open
@@ -2,6 +2,9 @@ package webhook
 
 import (
 	"context"
+	"errors"
+	"net/url"
+	"strings"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/rest"
@@ -21,6 +24,38 @@ func New(id snowflake.ID, token string, opts ...ConfigOpt) Client {
 	}
 }
 
+// ErrInvalidWebhookURL is returned when a webhook url could not be parsed.
+var ErrInvalidWebhookURL = errors.New("invalid webhook url")
+
+// NewWithURL creates a new Client by parsing the given webhook url like https://discord.com/api/webhooks/{id}/{token}.
+// A thread_id query parameter is used as thread messages are sent to by default.
+func NewWithURL(webhookURL string, opts ...ConfigOpt) (Client, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(parts)-2; i++ {
+		if parts[i] != "webhooks" {
+			continue
+		}
+		id, err := snowflake.Parse(parts[i+1])
+		if err != nil || parts[i+2] == "" {
+			return nil, ErrInvalidWebhookURL
+		}
+		if rawThreadID := u.Query().Get("thread_id"); rawThreadID != "" {
+			threadID, err := snowflake.Parse(rawThreadID)
+			if err != nil {
+				return nil, ErrInvalidWebhookURL
+			}
+			opts = append([]ConfigOpt{WithThreadID(threadID)}, opts...)
+		}
+		return New(id, parts[i+2], opts...), nil
+	}
+	return nil, ErrInvalidWebhookURL
+}
+
 type clientImpl struct {
 	id     snowflake.ID
 	token  string
@@ -35,8 +70,15 @@ func (c *clientImpl) Token() string {
 	return c.token
 }
 
+func (c *clientImpl) ThreadID() snowflake.ID {
+	return c.config.ThreadID
+}
+
 func (c *clientImpl) URL() string {
-	compiledRoute, _ := route.GetWebhook.Compile(nil, c.ID, c.Token)
+	compiledRoute, _ := route.GetWebhookWithToken.Compile(nil, c.id, c.token)
+	if c.config.ThreadID != 0 {
+		return compiledRoute.URL() + "?thread_id=" + c.config.ThreadID.String()
+	}
 	return compiledRoute.URL()
 }
 
@@ -73,7 +115,7 @@ func (c *clientImpl) CreateMessageInThread(messageCreate discord.WebhookMessageC
 }
 
 func (c *clientImpl) CreateMessage(messageCreate discord.WebhookMessageCreate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return c.CreateMessageInThread(messageCreate, 0, opts...)
+	return c.CreateMessageInThread(messageCreate, c.config.ThreadID, opts...)
 }
 
 func (c *clientImpl) CreateContent(content string, opts ...rest.RequestOpt) (*discord.Message, error) {
@@ -85,7 +127,7 @@ func (c *clientImpl) CreateEmbeds(embeds []discord.Embed, opts ...rest.RequestOp
 }
 
 func (c *clientImpl) UpdateMessage(messageID snowflake.ID, messageUpdate discord.WebhookMessageUpdate, opts ...rest.RequestOpt) (*discord.Message, error) {
-	return c.UpdateMessageInThread(messageID, messageUpdate, 0, opts...)
+	return c.UpdateMessageInThread(messageID, messageUpdate, c.config.ThreadID, opts...)
 }
 
 func (c *clientImpl) UpdateMessageInThread(messageID snowflake.ID, messageUpdate discord.WebhookMessageUpdate, threadID snowflake.ID, opts ...rest.RequestOpt) (*discord.Message, error) {
@@ -101,7 +143,7 @@ func (c *clientImpl) UpdateEmbeds(messageID snowflake.ID, embeds []discord.Embed
 }
 
 func (c *clientImpl) DeleteMessage(messageID snowflake.ID, opts ...rest.RequestOpt) error {
-	return c.DeleteMessageInThread(messageID, 0, opts...)
+	return c.DeleteMessageInThread(messageID, c.config.ThreadID, opts...)
 }
 
 func (c *clientImpl) DeleteMessageInThread(messageID snowflake.ID, threadID snowflake.ID, opts ...rest.RequestOpt) error {
@@ -4,6 +4,7 @@ import (
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/rest"
 	"github.com/disgoorg/log"
+	"github.com/disgoorg/snowflake/v2"
 )
 
 // DefaultConfig is the default configuration for the webhook client
@@ -21,6 +22,7 @@ type Config struct {
 	RestClientConfigOpts   []rest.ConfigOpt
 	Webhooks               rest.Webhooks
 	DefaultAllowedMentions *discord.AllowedMentions
+	ThreadID               snowflake.ID
 }
 
 // ConfigOpt is used to provide optional parameters to the webhook client
@@ -73,3 +75,10 @@ func WithDefaultAllowedMentions(allowedMentions discord.AllowedMentions) ConfigO
 		config.DefaultAllowedMentions = &allowedMentions
 	}
 }
+
+// WithThreadID sets the thread all messages are sent to, updated & deleted in by default
+func WithThreadID(threadID snowflake.ID) ConfigOpt {
+	return func(config *Config) {
+		config.ThreadID = threadID
+	}
+}
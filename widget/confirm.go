@@ -0,0 +1,101 @@
+package widget
+
+import (
+	"context"
+	"strings"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+)
+
+// DefaultConfirmConfig returns a ConfirmConfig with sensible defaults.
+func DefaultConfirmConfig() *ConfirmConfig {
+	return &ConfirmConfig{
+		YesLabel:         "Yes",
+		NoLabel:          "No",
+		UnauthorizedText: "You are not allowed to use this.",
+	}
+}
+
+// ConfirmConfig lets you configure a Confirm prompt.
+type ConfirmConfig struct {
+	YesLabel string
+	NoLabel  string
+
+	// UnauthorizedText is sent when another user than the one of the Target clicks a button.
+	UnauthorizedText string
+}
+
+// ConfirmOpt is a type alias for a function that takes a ConfirmConfig and is used to configure a Confirm prompt.
+type ConfirmOpt func(config *ConfirmConfig)
+
+// Apply applies the given ConfirmOpt(s) to the ConfirmConfig
+func (c *ConfirmConfig) Apply(opts []ConfirmOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithConfirmLabels sets the labels of the yes & no buttons.
+func WithConfirmLabels(yes string, no string) ConfirmOpt {
+	return func(config *ConfirmConfig) {
+		config.YesLabel = yes
+		config.NoLabel = no
+	}
+}
+
+// WithConfirmUnauthorizedText sets the text which is sent when another user than the one of the Target clicks a button.
+func WithConfirmUnauthorizedText(unauthorizedText string) ConfirmOpt {
+	return func(config *ConfirmConfig) {
+		config.UnauthorizedText = unauthorizedText
+	}
+}
+
+// Confirm sends the given discord.MessageCreate with a yes & no button to the Target and waits for its user to click one of them.
+// It returns whether yes was clicked. The buttons are removed after a click or when the context is done, in which case the context error is returned.
+// Confirm blocks and therefore needs to be called in its own goroutine when used from an event listener without async events enabled.
+func Confirm(ctx context.Context, target Target, messageCreate discord.MessageCreate, opts ...ConfirmOpt) (bool, error) {
+	config := DefaultConfirmConfig()
+	config.Apply(opts)
+
+	prefix := "confirm:" + insecurerandstr.RandStr(32) + ":"
+	clicks, stop := bot.NewEventCollector(target.client, func(e *events.ComponentInteractionCreate) bool {
+		return strings.HasPrefix(e.Data.CustomID().String(), prefix)
+	})
+	defer stop()
+
+	messageCreate.Components = append(messageCreate.Components, discord.NewActionRow(
+		discord.NewSuccessButton(config.YesLabel, discord.CustomID(prefix+"yes")),
+		discord.NewDangerButton(config.NoLabel, discord.CustomID(prefix+"no")),
+	))
+	message, err := target.send(ctx, messageCreate)
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			// use a fresh context as the one passed in is already done
+			if err = message.update(context.Background(), discord.MessageUpdate{Components: removeComponents()}); err != nil {
+				target.client.Logger().Error("failed to remove confirm buttons: ", err)
+			}
+			return false, ctx.Err()
+
+		case e := <-clicks:
+			if e.User().ID != target.userID {
+				if err = e.CreateMessage(discord.NewMessageCreateBuilder().
+					SetContent(config.UnauthorizedText).
+					SetEphemeral(true).
+					Build(),
+				); err != nil {
+					target.client.Logger().Error("failed to respond to unauthorized confirm click: ", err)
+				}
+				continue
+			}
+			return strings.HasSuffix(e.Data.CustomID().String(), ":yes"), e.UpdateMessage(discord.MessageUpdate{Components: removeComponents()})
+		}
+	}
+}
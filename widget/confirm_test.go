@@ -0,0 +1,95 @@
+package widget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/discord"
+)
+
+type confirmResult struct {
+	confirmed bool
+	err       error
+}
+
+// startConfirm runs Confirm in its own goroutine and returns the message it sent.
+func startConfirm(t *testing.T, ctx context.Context, env *testEnv) (discord.Message, <-chan confirmResult) {
+	results := make(chan confirmResult, 1)
+	go func() {
+		confirmed, err := Confirm(ctx, env.target, discord.MessageCreate{Content: "Are you sure?"})
+		results <- confirmResult{confirmed: confirmed, err: err}
+	}()
+	return env.waitForMessage(t), results
+}
+
+func waitForResult(t *testing.T, results <-chan confirmResult) confirmResult {
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for confirm")
+		return confirmResult{}
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	tests := map[string]struct {
+		button    int
+		confirmed bool
+	}{
+		"accept": {button: 0, confirmed: true},
+		"deny":   {button: 1, confirmed: false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := newTestEnv(t)
+			message, results := startConfirm(t, context.Background(), env)
+			assert.Equal(t, "Are you sure?", message.Content)
+			sent := buttons(t, message.Components)
+			require.Len(t, sent, 2)
+
+			responses := make(chan testResponse, 1)
+			// other users are told they can't use the buttons and don't end the prompt
+			env.client.EventManager().DispatchEvent(env.clickEvent(t, ownerID+1, message, sent[tt.button].CustomID, responses))
+			response := receive(t, responses)
+			assert.Equal(t, discord.InteractionResponseTypeCreateMessage, response.Type)
+			messageCreate, ok := response.Data.(discord.MessageCreate)
+			require.True(t, ok)
+			assert.Equal(t, DefaultConfirmConfig().UnauthorizedText, messageCreate.Content)
+
+			env.client.EventManager().DispatchEvent(env.clickEvent(t, ownerID, message, sent[tt.button].CustomID, responses))
+			result := waitForResult(t, results)
+			require.NoError(t, result.err)
+			assert.Equal(t, tt.confirmed, result.confirmed)
+
+			response = receive(t, responses)
+			assert.Equal(t, discord.InteractionResponseTypeUpdateMessage, response.Type)
+			messageUpdate, ok := response.Data.(discord.MessageUpdate)
+			require.True(t, ok)
+			require.NotNil(t, messageUpdate.Components)
+			assert.Empty(t, *messageUpdate.Components)
+		})
+	}
+}
+
+func TestConfirm_Timeout(t *testing.T) {
+	env := newTestEnv(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	message, results := startConfirm(t, ctx, env)
+	require.Len(t, buttons(t, message.Components), 2)
+
+	result := waitForResult(t, results)
+	assert.ErrorIs(t, result.err, context.DeadlineExceeded)
+	assert.False(t, result.confirmed)
+
+	// the buttons are removed from the message when the prompt times out
+	stored, ok := env.server.Message(env.channelID, message.ID)
+	require.True(t, ok)
+	assert.Empty(t, stored.Components)
+}
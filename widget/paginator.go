@@ -0,0 +1,320 @@
+package widget
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/disgoorg/disgo/component"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/internal/insecurerandstr"
+	"github.com/disgoorg/disgo/modal"
+)
+
+var (
+	// ErrNoPages is returned when a Paginator is sent without any pages.
+	ErrNoPages = errors.New("paginator has no pages")
+
+	// ErrNoTTL is returned when a Paginator is sent with a component.Session which never expires, as its pages would be kept in memory forever.
+	ErrNoTTL = errors.New("paginator session has no ttl")
+)
+
+// Pages provides the embeds of a paginated message.
+type Pages struct {
+	// Count is the number of pages.
+	Count int
+
+	// Page returns the embed of the page with the given index, starting at 0.
+	Page func(page int) discord.Embed
+}
+
+// NewPages returns Pages with one page per given discord.Embed.
+func NewPages(embeds ...discord.Embed) Pages {
+	return Pages{
+		Count: len(embeds),
+		Page: func(page int) discord.Embed {
+			return embeds[page]
+		},
+	}
+}
+
+// Paginator sends embeds split into pages with buttons to navigate between them.
+// Only the user who triggered the Target can use the buttons. When the component.Session expires the buttons are removed.
+type Paginator interface {
+	// Send sends the first page of the given Pages to the Target.
+	// The user of the Target is always an owner of the component.Session. Use component.WithOwners to allow more users.
+	// The component.Session must expire, so a TTL of 0 returns ErrNoTTL.
+	Send(ctx context.Context, target Target, pages Pages, opts ...component.SessionOpt) error
+}
+
+// DefaultPaginatorConfig returns a PaginatorConfig with sensible defaults.
+func DefaultPaginatorConfig() *PaginatorConfig {
+	return &PaginatorConfig{
+		ViewName:    "paginator",
+		FirstLabel:  "⏮",
+		PrevLabel:   "◀",
+		NextLabel:   "▶",
+		LastLabel:   "⏭",
+		JumpTitle:   "Jump to page",
+		JumpLabel:   "Page",
+		ExpiredText: "This paginator has expired.",
+	}
+}
+
+// PaginatorConfig lets you configure your Paginator instance.
+type PaginatorConfig struct {
+	// ViewName is the name of the component.View registered by the Paginator.
+	ViewName string
+
+	FirstLabel string
+	PrevLabel  string
+	NextLabel  string
+	LastLabel  string
+
+	// JumpTitle & JumpLabel are used for the modal which opens when clicking on the page counter.
+	JumpTitle string
+	JumpLabel string
+
+	// ExpiredText is sent when a button of a paginator is used whose pages are not known anymore.
+	ExpiredText string
+}
+
+// PaginatorOpt is a type alias for a function that takes a PaginatorConfig and is used to configure your Paginator.
+type PaginatorOpt func(config *PaginatorConfig)
+
+// Apply applies the given PaginatorOpt(s) to the PaginatorConfig
+func (c *PaginatorConfig) Apply(opts []PaginatorOpt) {
+	for _, opt := range opts {
+		opt(c)
+	}
+}
+
+// WithPaginatorViewName sets the name of the component.View registered by the Paginator.
+func WithPaginatorViewName(viewName string) PaginatorOpt {
+	return func(config *PaginatorConfig) {
+		config.ViewName = viewName
+	}
+}
+
+// WithPaginatorLabels sets the labels of the first, previous, next & last buttons.
+func WithPaginatorLabels(first string, prev string, next string, last string) PaginatorOpt {
+	return func(config *PaginatorConfig) {
+		config.FirstLabel = first
+		config.PrevLabel = prev
+		config.NextLabel = next
+		config.LastLabel = last
+	}
+}
+
+// WithPaginatorJumpModal sets the title & label of the modal which opens when clicking on the page counter.
+func WithPaginatorJumpModal(title string, label string) PaginatorOpt {
+	return func(config *PaginatorConfig) {
+		config.JumpTitle = title
+		config.JumpLabel = label
+	}
+}
+
+// WithPaginatorExpiredText sets the text which is sent when a button of a paginator is used whose pages are not known anymore.
+func WithPaginatorExpiredText(expiredText string) PaginatorOpt {
+	return func(config *PaginatorConfig) {
+		config.ExpiredText = expiredText
+	}
+}
+
+var _ Paginator = (*paginatorImpl)(nil)
+
+// NewPaginator creates a new Paginator and registers its component.View with the given component.Manager.
+// Pages are kept in memory, so buttons of paginators sent before a restart are removed when used.
+func NewPaginator(manager component.Manager, opts ...PaginatorOpt) Paginator {
+	config := DefaultPaginatorConfig()
+	config.Apply(opts)
+
+	p := &paginatorImpl{
+		manager:    manager,
+		config:     *config,
+		paginators: map[string]*paginatorState{},
+	}
+	manager.AddViews(component.View{
+		Name: config.ViewName,
+		Handlers: map[string]component.Handler{
+			"first": p.onNavigate,
+			"prev":  p.onNavigate,
+			"next":  p.onNavigate,
+			"last":  p.onNavigate,
+			"jump":  p.onJump,
+		},
+		OnExpire: func(session *component.Session) {
+			p.remove(session.State)
+		},
+		RemoveOnExpire: true,
+	})
+	return p
+}
+
+type paginatorState struct {
+	pages Pages
+	page  int
+}
+
+type paginatorImpl struct {
+	manager component.Manager
+	config  PaginatorConfig
+
+	paginators map[string]*paginatorState
+	mu         sync.Mutex
+}
+
+func (p *paginatorImpl) Send(ctx context.Context, target Target, pages Pages, opts ...component.SessionOpt) error {
+	if pages.Count <= 0 {
+		return ErrNoPages
+	}
+	id := insecurerandstr.RandStr(16)
+	session, err := p.manager.NewSession(p.config.ViewName, id, append([]component.SessionOpt{component.WithOwners(target.userID)}, opts...)...)
+	if err != nil {
+		return err
+	}
+	if session.ExpiresAt.IsZero() {
+		_ = p.manager.EndSession(ctx, session)
+		return ErrNoTTL
+	}
+
+	p.mu.Lock()
+	p.paginators[id] = &paginatorState{pages: pages}
+	p.mu.Unlock()
+
+	message, err := target.send(ctx, discord.MessageCreate{
+		Embeds:     []discord.Embed{pages.Page(0)},
+		Components: p.components(session, 0, pages.Count),
+	})
+	if err != nil {
+		p.remove(id)
+		return err
+	}
+	message.bind(session)
+	return nil
+}
+
+func (p *paginatorImpl) components(session *component.Session, page int, count int) []discord.ContainerComponent {
+	return []discord.ContainerComponent{discord.NewActionRow(
+		discord.NewSecondaryButton(p.config.FirstLabel, session.CustomID("first")).WithDisabled(page == 0),
+		discord.NewSecondaryButton(p.config.PrevLabel, session.CustomID("prev")).WithDisabled(page == 0),
+		discord.NewPrimaryButton(fmt.Sprintf("%d/%d", page+1, count), session.CustomID("jump")).WithDisabled(count == 1),
+		discord.NewSecondaryButton(p.config.NextLabel, session.CustomID("next")).WithDisabled(page == count-1),
+		discord.NewSecondaryButton(p.config.LastLabel, session.CustomID("last")).WithDisabled(page == count-1),
+	)}
+}
+
+func (p *paginatorImpl) messageUpdate(session *component.Session, page int, count int, embed discord.Embed) discord.MessageUpdate {
+	components := p.components(session, page, count)
+	return discord.MessageUpdate{
+		Embeds:     &[]discord.Embed{embed},
+		Components: &components,
+	}
+}
+
+// setPage sets the page of the paginator of the component.Session and returns the update for its message.
+func (p *paginatorImpl) setPage(session *component.Session, setPage func(page int, count int) int) (discord.MessageUpdate, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	state, ok := p.paginators[session.State]
+	if !ok {
+		return discord.MessageUpdate{}, false
+	}
+	page := setPage(state.page, state.pages.Count)
+	if page < 0 {
+		page = 0
+	} else if page >= state.pages.Count {
+		page = state.pages.Count - 1
+	}
+	state.page = page
+	return p.messageUpdate(session, page, state.pages.Count, state.pages.Page(page)), true
+}
+
+func (p *paginatorImpl) remove(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.paginators, id)
+}
+
+func (p *paginatorImpl) expired(e *component.Event) error {
+	return e.UpdateMessage(discord.MessageUpdate{
+		Content:    &p.config.ExpiredText,
+		Components: removeComponents(),
+	})
+}
+
+func (p *paginatorImpl) onNavigate(e *component.Event) error {
+	messageUpdate, ok := p.setPage(e.Session, func(page int, count int) int {
+		switch e.Action {
+		case "first":
+			return 0
+		case "prev":
+			return page - 1
+		case "next":
+			return page + 1
+		default:
+			return count - 1
+		}
+	})
+	if !ok {
+		return p.expired(e)
+	}
+	return e.UpdateMessage(messageUpdate)
+}
+
+func (p *paginatorImpl) onJump(e *component.Event) error {
+	p.mu.Lock()
+	state, ok := p.paginators[e.Session.State]
+	var count int
+	if ok {
+		count = state.pages.Count
+	}
+	p.mu.Unlock()
+	if !ok {
+		return p.expired(e)
+	}
+
+	jumpModal := modal.New(p.config.JumpTitle, modal.NewShortField("page", p.config.JumpLabel).
+		WithPlaceholder(fmt.Sprintf("1-%d", count)).
+		WithRequired(true).
+		WithValidate(func(value string) error {
+			page, err := strconv.Atoi(value)
+			if err != nil || page < 1 || page > count {
+				return fmt.Errorf("must be a number between 1 and %d", count)
+			}
+			return nil
+		}),
+	)
+
+	// modal.Open blocks until the modal is submitted, so we can't wait for it in the event listener
+	session := e.Session
+	go func() {
+		ctx := context.Background()
+		if !session.ExpiresAt.IsZero() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithDeadline(ctx, session.ExpiresAt)
+			defer cancel()
+		}
+		submission, err := modal.Open(ctx, e, jumpModal)
+		if err != nil {
+			var validationErr *modal.ValidationError
+			if !errors.As(err, &validationErr) && !errors.Is(err, context.DeadlineExceeded) {
+				p.manager.Logger().Error("failed to open paginator jump modal: ", err)
+			}
+			return
+		}
+		page, _ := submission.Values.Int("page")
+		messageUpdate, ok := p.setPage(session, func(int, int) int {
+			return page - 1
+		})
+		if !ok {
+			return
+		}
+		if err = submission.Event.UpdateMessage(messageUpdate); err != nil {
+			p.manager.Logger().Error("failed to update paginator after jump: ", err)
+		}
+	}()
+	return nil
+}
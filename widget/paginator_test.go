@@ -0,0 +1,93 @@
+package widget
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo/component"
+	"github.com/disgoorg/disgo/discord"
+)
+
+func TestPaginator(t *testing.T) {
+	env := newTestEnv(t)
+	manager := component.New(env.client)
+	defer manager.Close(context.Background())
+	paginator := NewPaginator(manager)
+
+	require.ErrorIs(t, paginator.Send(context.Background(), env.target, Pages{}), ErrNoPages)
+	// a session which never expires would keep the pages in memory forever
+	require.ErrorIs(t, paginator.Send(context.Background(), env.target, NewPages(discord.Embed{Title: "one"}), component.WithTTL(0)), ErrNoTTL)
+	assert.Empty(t, paginator.(*paginatorImpl).paginators)
+	require.NoError(t, paginator.Send(context.Background(), env.target, NewPages(
+		discord.Embed{Title: "one"},
+		discord.Embed{Title: "two"},
+		discord.Embed{Title: "three"},
+	)))
+
+	message := env.lastMessage(t)
+	require.Len(t, message.Embeds, 1)
+	assert.Equal(t, "one", message.Embeds[0].Title)
+	sent := buttons(t, message.Components)
+	require.Len(t, sent, 5)
+	assert.True(t, sent[0].Disabled)
+	assert.True(t, sent[1].Disabled)
+	assert.Equal(t, "1/3", sent[2].Label)
+	assert.False(t, sent[3].Disabled)
+	assert.False(t, sent[4].Disabled)
+	first, prev, jump, next, last := sent[0].CustomID, sent[1].CustomID, sent[2].CustomID, sent[3].CustomID, sent[4].CustomID
+
+	responses := make(chan testResponse, 1)
+	click := func(customID discord.CustomID) testResponse {
+		manager.OnEvent(env.clickEvent(t, ownerID, message, customID, responses))
+		return receive(t, responses)
+	}
+	assertPage := func(response testResponse, title string, label string) {
+		t.Helper()
+		require.Equal(t, discord.InteractionResponseTypeUpdateMessage, response.Type)
+		messageUpdate, ok := response.Data.(discord.MessageUpdate)
+		require.True(t, ok)
+		require.NotNil(t, messageUpdate.Embeds)
+		assert.Equal(t, title, (*messageUpdate.Embeds)[0].Title)
+		require.NotNil(t, messageUpdate.Components)
+		assert.Equal(t, label, buttons(t, *messageUpdate.Components)[2].Label)
+	}
+
+	t.Run("navigate", func(t *testing.T) {
+		assertPage(click(next), "two", "2/3")
+		assertPage(click(last), "three", "3/3")
+		// the page stays in bounds even if a stale next button is clicked
+		assertPage(click(next), "three", "3/3")
+		assertPage(click(prev), "two", "2/3")
+		assertPage(click(first), "one", "1/3")
+		assertPage(click(prev), "one", "1/3")
+	})
+
+	t.Run("jump", func(t *testing.T) {
+		openModal := func() discord.ModalCreate {
+			response := click(jump)
+			require.Equal(t, discord.InteractionResponseTypeModal, response.Type)
+			modalCreate, ok := response.Data.(discord.ModalCreate)
+			require.True(t, ok)
+			return modalCreate
+		}
+
+		modalCreate := openModal()
+		env.client.EventManager().DispatchEvent(env.modalSubmitEvent(t, ownerID, discord.CustomID(modalCreate.CustomID), map[discord.CustomID]string{"page": "4"}, responses))
+		response := receive(t, responses)
+		// out of bounds pages are rejected by the modal validation
+		assert.Equal(t, discord.InteractionResponseTypeCreateMessage, response.Type)
+
+		modalCreate = openModal()
+		env.client.EventManager().DispatchEvent(env.modalSubmitEvent(t, ownerID, discord.CustomID(modalCreate.CustomID), map[discord.CustomID]string{"page": "3"}, responses))
+		assertPage(receive(t, responses), "three", "3/3")
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		manager.OnEvent(env.clickEvent(t, ownerID+1, message, next, responses))
+		response := receive(t, responses)
+		assert.Equal(t, discord.InteractionResponseTypeCreateMessage, response.Type)
+	})
+}
@@ -0,0 +1,109 @@
+// Package widget provides ready-made interactive messages like a Paginator for embeds and a Confirm prompt.
+// Widgets can be sent in response to slash commands, components, modals or normal messages and work with the gateway as well as the httpserver.
+package widget
+
+import (
+	"context"
+
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/component"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/rest"
+	"github.com/disgoorg/snowflake/v2"
+)
+
+// InteractionEvent is implemented by all interaction events which can be responded to with a message.
+// This includes *events.ApplicationCommandInteractionCreate, *events.ComponentInteractionCreate & *events.ModalSubmitInteractionCreate.
+type InteractionEvent interface {
+	Client() bot.Client
+	User() discord.User
+	Token() string
+	CreateMessage(messageCreate discord.MessageCreate, opts ...rest.RequestOpt) error
+}
+
+// Target is where a widget is sent to. Create it with InteractionTarget or MessageTarget.
+type Target struct {
+	client      bot.Client
+	userID      snowflake.ID
+	interaction InteractionEvent
+	channelID   snowflake.ID
+	messageID   snowflake.ID
+}
+
+// InteractionTarget returns a Target which responds to the given interaction.
+func InteractionTarget(event InteractionEvent) Target {
+	return Target{
+		client:      event.Client(),
+		userID:      event.User().ID,
+		interaction: event,
+	}
+}
+
+// MessageTarget returns a Target which replies to the given message.
+func MessageTarget(event *events.MessageCreate) Target {
+	return Target{
+		client:    event.Client(),
+		userID:    event.Message.Author.ID,
+		channelID: event.ChannelID,
+		messageID: event.MessageID,
+	}
+}
+
+// Client returns the bot.Client of the Target.
+func (t Target) Client() bot.Client {
+	return t.client
+}
+
+// UserID returns the id of the user who triggered the Target.
+func (t Target) UserID() snowflake.ID {
+	return t.userID
+}
+
+// sentMessage is a message sent to a Target which can be edited later on.
+type sentMessage struct {
+	client           bot.Client
+	interactionToken string
+	channelID        snowflake.ID
+	messageID        snowflake.ID
+}
+
+func (t Target) send(ctx context.Context, messageCreate discord.MessageCreate) (*sentMessage, error) {
+	if t.interaction != nil {
+		if err := t.interaction.CreateMessage(messageCreate, rest.WithCtx(ctx)); err != nil {
+			return nil, err
+		}
+		return &sentMessage{client: t.client, interactionToken: t.interaction.Token()}, nil
+	}
+
+	if t.messageID != 0 && messageCreate.MessageReference == nil {
+		messageCreate.MessageReference = &discord.MessageReference{MessageID: &t.messageID}
+	}
+	message, err := t.client.Rest().CreateMessage(t.channelID, messageCreate, rest.WithCtx(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &sentMessage{client: t.client, channelID: message.ChannelID, messageID: message.ID}, nil
+}
+
+func (m *sentMessage) bind(session *component.Session) {
+	if m.interactionToken != "" {
+		session.BindInteraction(m.interactionToken)
+		return
+	}
+	session.Bind(discord.Message{ID: m.messageID, ChannelID: m.channelID})
+}
+
+func (m *sentMessage) update(ctx context.Context, messageUpdate discord.MessageUpdate) error {
+	var err error
+	if m.interactionToken != "" {
+		_, err = m.client.Rest().UpdateInteractionResponse(m.client.ApplicationID(), m.interactionToken, messageUpdate, rest.WithCtx(ctx))
+	} else {
+		_, err = m.client.Rest().UpdateMessage(m.channelID, m.messageID, messageUpdate, rest.WithCtx(ctx))
+	}
+	return err
+}
+
+func removeComponents() *[]discord.ContainerComponent {
+	return &[]discord.ContainerComponent{}
+}
@@ -0,0 +1,166 @@
+package widget
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/disgoorg/snowflake/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disgoorg/disgo"
+	"github.com/disgoorg/disgo/bot"
+	"github.com/disgoorg/disgo/discord"
+	"github.com/disgoorg/disgo/disgotest"
+	"github.com/disgoorg/disgo/events"
+	"github.com/disgoorg/disgo/json"
+	"github.com/disgoorg/disgo/rest"
+)
+
+const ownerID = snowflake.ID(1000)
+
+type testResponse struct {
+	Type discord.InteractionResponseType
+	Data discord.InteractionResponseData
+}
+
+type testEnv struct {
+	client    bot.Client
+	server    disgotest.Server
+	channelID snowflake.ID
+	target    Target
+}
+
+// newTestEnv returns a bot.Client talking to a disgotest.Server and a Target replying to a message of ownerID.
+func newTestEnv(t *testing.T) *testEnv {
+	server := disgotest.New()
+	t.Cleanup(server.Close)
+
+	client, err := disgo.New("MTIz.test.token", bot.WithRestClientConfigOpts(server.RestConfigOpts()...))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close(context.Background())
+	})
+
+	guild := server.AddGuild(discord.Guild{Name: "Test Guild"})
+	channel := server.CreateGuildChannel(guild.ID, discord.GuildTextChannelCreate{Name: "general"})
+	message := server.AddMessage(discord.Message{
+		ChannelID: channel.ID(),
+		Author:    discord.User{ID: ownerID, Username: "owner"},
+		Content:   "!widget",
+	})
+
+	return &testEnv{
+		client:    client,
+		server:    server,
+		channelID: channel.ID(),
+		target: MessageTarget(&events.MessageCreate{GenericMessage: &events.GenericMessage{
+			GenericEvent: events.NewGenericEvent(client, 0, 0),
+			MessageID:    message.ID,
+			Message:      message,
+			ChannelID:    channel.ID(),
+		}}),
+	}
+}
+
+// lastMessage returns the last message sent to the channel of the testEnv.
+func (e *testEnv) lastMessage(t *testing.T) discord.Message {
+	messages := e.server.Messages(e.channelID)
+	require.NotEmpty(t, messages)
+	return messages[len(messages)-1]
+}
+
+// waitForMessage waits until the widget sent its message to the channel of the testEnv.
+func (e *testEnv) waitForMessage(t *testing.T) discord.Message {
+	require.Eventually(t, func() bool {
+		return len(e.server.Messages(e.channelID)) > 1
+	}, time.Second, 10*time.Millisecond)
+	return e.lastMessage(t)
+}
+
+// clickEvent returns a ComponentInteractionCreate of the given user clicking the button with the given custom id on the message.
+// Responses to the interaction are sent to the responses channel.
+func (e *testEnv) clickEvent(t *testing.T, userID snowflake.ID, message discord.Message, customID discord.CustomID, responses chan<- testResponse) *events.ComponentInteractionCreate {
+	messageData, err := json.Marshal(message)
+	require.NoError(t, err)
+
+	var interaction discord.ComponentInteraction
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{
+		"id": "1",
+		"application_id": "123",
+		"type": 3,
+		"token": "token",
+		"version": 1,
+		"channel_id": "%s",
+		"user": {"id": "%s", "username": "user"},
+		"data": {"component_type": 2, "custom_id": "%s"},
+		"message": %s
+	}`, e.channelID, userID, customID, messageData)), &interaction))
+
+	return &events.ComponentInteractionCreate{
+		GenericEvent:         events.NewGenericEvent(e.client, 0, 0),
+		ComponentInteraction: interaction,
+		Respond:              respondTo(responses),
+	}
+}
+
+// modalSubmitEvent returns a ModalSubmitInteractionCreate of the given user submitting the modal with the given custom id and text input values.
+func (e *testEnv) modalSubmitEvent(t *testing.T, userID snowflake.ID, customID discord.CustomID, values map[discord.CustomID]string, responses chan<- testResponse) *events.ModalSubmitInteractionCreate {
+	var rows []discord.ContainerComponent
+	for inputID, value := range values {
+		rows = append(rows, discord.NewActionRow(discord.TextInputComponent{CustomID: inputID, Style: discord.TextInputStyleShort, Value: value}))
+	}
+	componentsData, err := json.Marshal(rows)
+	require.NoError(t, err)
+
+	var interaction discord.ModalSubmitInteraction
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{
+		"id": "2",
+		"application_id": "123",
+		"type": 5,
+		"token": "token",
+		"version": 1,
+		"channel_id": "%s",
+		"user": {"id": "%s", "username": "user"},
+		"data": {"custom_id": "%s", "components": %s}
+	}`, e.channelID, userID, customID, componentsData)), &interaction))
+
+	return &events.ModalSubmitInteractionCreate{
+		GenericEvent:           events.NewGenericEvent(e.client, 0, 0),
+		ModalSubmitInteraction: interaction,
+		Respond:                respondTo(responses),
+	}
+}
+
+func respondTo(responses chan<- testResponse) events.InteractionResponderFunc {
+	return func(rType discord.InteractionResponseType, data discord.InteractionResponseData, _ ...rest.RequestOpt) error {
+		responses <- testResponse{Type: rType, Data: data}
+		return nil
+	}
+}
+
+// receive waits for the next response to an interaction.
+func receive(t *testing.T, responses <-chan testResponse) testResponse {
+	select {
+	case response := <-responses:
+		return response
+	case <-time.After(time.Second):
+		require.FailNow(t, "timed out waiting for interaction response")
+		return testResponse{}
+	}
+}
+
+// buttons returns the buttons of the first action row of the given components.
+func buttons(t *testing.T, components []discord.ContainerComponent) []discord.ButtonComponent {
+	require.NotEmpty(t, components)
+	row, ok := components[0].(discord.ActionRowComponent)
+	require.True(t, ok)
+	var buttons []discord.ButtonComponent
+	for _, component := range row {
+		button, ok := component.(discord.ButtonComponent)
+		require.True(t, ok)
+		buttons = append(buttons, button)
+	}
+	return buttons
+}